@@ -0,0 +1,132 @@
+package main
+
+import "strings"
+
+// computeByteClasses partitions the 256 byte values into equivalence
+// classes such that two bytes share a class iff every state in ac
+// transitions identically on them. Patterns built from a small alphabet
+// (typical for legal phrases) collapse to a handful of classes, since most
+// of the 256 possible bytes never appear and are therefore interchangeable
+// "other" bytes as far as the DFA is concerned.
+func computeByteClasses(ac *AhoCorasickAutomaton) (classes [256]uint8, numClasses int) {
+	columnOf := func(b int) string {
+		buf := make([]byte, 0, ac.stateCount*4)
+		for s := 0; s < ac.stateCount; s++ {
+			n := ac.states[s].next[b]
+			buf = append(buf, byte(n), byte(n>>8), byte(n>>16), byte(n>>24))
+		}
+		return string(buf)
+	}
+
+	seen := make(map[string]uint8)
+	for b := 0; b < 256; b++ {
+		key := columnOf(b)
+		id, ok := seen[key]
+		if !ok {
+			id = uint8(len(seen))
+			seen[key] = id
+		}
+		classes[b] = id
+	}
+
+	return classes, len(seen)
+}
+
+// CompactAutomaton is a byte-class-compressed view of an
+// AhoCorasickAutomaton: the wide `next []int32` (256 entries) per state is
+// replaced by `next[state*numClasses+classes[b]]`, cutting transition-table
+// memory roughly 256/numClasses-fold for pattern sets drawn from a small
+// alphabet.
+type CompactAutomaton struct {
+	classes      [256]uint8
+	numClasses   int
+	next         []int32 // stateCount * numClasses
+	failure      []int32
+	outputs      [][]int // per-state output list, same shape as ACState.outputs
+	stateCount   int
+	patternCount int
+}
+
+// newCompactAutomaton builds the compressed form of ac.
+func newCompactAutomaton(ac *AhoCorasickAutomaton) *CompactAutomaton {
+	classes, numClasses := computeByteClasses(ac)
+
+	c := &CompactAutomaton{
+		classes:      classes,
+		numClasses:   numClasses,
+		next:         make([]int32, ac.stateCount*numClasses),
+		failure:      make([]int32, ac.stateCount),
+		outputs:      make([][]int, ac.stateCount),
+		stateCount:   ac.stateCount,
+		patternCount: ac.patternCount,
+	}
+
+	for s := 0; s < ac.stateCount; s++ {
+		c.failure[s] = int32(ac.states[s].failure)
+		c.outputs[s] = ac.states[s].outputs
+
+		for b := 0; b < 256; b++ {
+			class := int(classes[b])
+			c.next[s*numClasses+class] = ac.states[s].next[b]
+		}
+	}
+
+	return c
+}
+
+// MemoryBytes estimates the compact transition table's footprint.
+func (c *CompactAutomaton) MemoryBytes() int {
+	return len(c.next) * 4 // int32
+}
+
+// WideTableMemoryBytes estimates the footprint of the uncompressed
+// [stateCount][256]int32 table this automaton was compressed from.
+func (c *CompactAutomaton) WideTableMemoryBytes() int {
+	return c.stateCount * 256 * 4 // int32 is 4 bytes
+}
+
+// compactMatches runs the active automaton through its byte-class-compressed
+// form. It mirrors rawMatches's ASCII text preprocessing so turning
+// MatcherOptions.ByteClasses on changes memory layout only, not semantics.
+func (m *AhoCorasickMatcher) compactMatches(text string) []MatchResult {
+	c := m.compact
+	lowerText := strings.ToLower(text)
+
+	var results []MatchResult
+	state := 0
+
+	for i, char := range lowerText {
+		b := int(char)
+		if b >= 256 {
+			continue // Skip non-ASCII characters
+		}
+		class := int(c.classes[b])
+
+		for state != 0 && c.next[state*c.numClasses+class] == 0 {
+			state = int(c.failure[state])
+		}
+		state = int(c.next[state*c.numClasses+class])
+
+		for _, patternID := range c.outputs[state] {
+			if patternID >= len(m.patterns) {
+				continue
+			}
+
+			pattern := m.patterns[patternID]
+			patternLen := len(pattern)
+			offset := i - patternLen + 1
+
+			if offset >= 0 && offset+patternLen <= len(text) {
+				results = append(results, MatchResult{
+					Offset:     uint64(offset),
+					Length:     uint64(patternLen),
+					PatternID:  uint32(patternID),
+					Confidence: 95,
+					Text:       text[offset : offset+patternLen],
+				})
+			}
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestByteClassesMatchWideDFA(t *testing.T) {
+	patterns := LegalPatterns
+	automaton, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+
+	wide := &AhoCorasickMatcher{patterns: patterns, automaton: automaton, initialized: true}
+	compact := &AhoCorasickMatcher{patterns: patterns, automaton: automaton, initialized: true, compact: newCompactAutomaton(automaton)}
+
+	texts := []string{
+		"he said the defendant was guilty",
+		"according to witnesses, she told me about it",
+		"allegedly reportedly sources say this happened",
+		"clean legal text with no hearsay indicators",
+	}
+
+	for _, text := range texts {
+		wantResults := wide.rawMatches(text)
+		gotResults := compact.rawMatches(text)
+
+		if len(gotResults) != len(wantResults) {
+			t.Fatalf("%q: compact found %d matches, wide DFA found %d", text, len(gotResults), len(wantResults))
+		}
+		for i := range wantResults {
+			if gotResults[i] != wantResults[i] {
+				t.Fatalf("%q: match %d differs: compact=%+v wide=%+v", text, i, gotResults[i], wantResults[i])
+			}
+		}
+	}
+}
+
+func TestByteClassesShrinkMemory(t *testing.T) {
+	automaton, err := buildAhoCorasickAutomaton(LegalPatterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+
+	compact := newCompactAutomaton(automaton)
+
+	if compact.numClasses >= 256 {
+		t.Fatalf("expected byte classes to collapse well below 256 for %d legal patterns, got %d", len(LegalPatterns), compact.numClasses)
+	}
+	if compact.MemoryBytes() >= compact.WideTableMemoryBytes() {
+		t.Fatalf("expected compact table (%d bytes) to be smaller than wide table (%d bytes)",
+			compact.MemoryBytes(), compact.WideTableMemoryBytes())
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MatchKind selects how overlapping matches reported by the DFA are
+// resolved, mirroring the match-kind knob exposed by the Rust aho-corasick
+// crate.
+type MatchKind int
+
+const (
+	// MatchStandard reports every output as soon as its state is entered,
+	// including matches that overlap each other. This is the matcher's
+	// original behavior.
+	MatchStandard MatchKind = iota
+
+	// MatchLeftmostFirst keeps, for each starting position, only the
+	// longest match belonging to the earliest-inserted pattern that
+	// begins there (insertion order follows the input `patterns` slice).
+	MatchLeftmostFirst
+
+	// MatchLeftmostLongest keeps, for each starting position, only the
+	// single longest match regardless of pattern insertion order.
+	MatchLeftmostLongest
+)
+
+// MatcherOptions configures NewAhoCorasickMatcherWithOptions.
+type MatcherOptions struct {
+	// PatternsFile is loaded the same way as NewAhoCorasickMatcher's
+	// argument; empty uses the default LegalPatterns.
+	PatternsFile string
+
+	// MatchKind selects which overlapping matches survive. Defaults to
+	// MatchStandard.
+	MatchKind MatchKind
+
+	// ASCIICaseInsensitive keeps today's behavior: ASCII patterns/input are
+	// matched case-insensitively, and bytes belonging to multi-byte UTF-8
+	// runes are invisible to the automaton. This is the default and is the
+	// cheaper of the two case-insensitive modes.
+	ASCIICaseInsensitive bool
+
+	// UnicodeCaseInsensitive switches to a full UTF-8 automaton: patterns
+	// and input are folded with golang.org/x/text/cases (not strings.ToLower,
+	// which mishandles things like Turkish İ or German ß), every UTF-8 byte
+	// is a valid transition, and match offsets are translated back through
+	// to the untouched original input.
+	UnicodeCaseInsensitive bool
+
+	// ByteClasses replaces the wide [256]int per-state transition table
+	// with a compact one indexed by byte equivalence class, trading a bit
+	// of indirection for a large memory win on big pattern sets. Not yet
+	// supported together with UnicodeCaseInsensitive.
+	ByteClasses bool
+}
+
+// NewAhoCorasickMatcherWithOptions creates a matcher with a selectable
+// match-kind and case-folding mode in addition to the usual pattern loading.
+func NewAhoCorasickMatcherWithOptions(opts MatcherOptions) (*AhoCorasickMatcher, error) {
+	matcher, err := NewAhoCorasickMatcher(opts.PatternsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher.matchKind = opts.MatchKind
+
+	if opts.UnicodeCaseInsensitive {
+		automaton, err := buildAhoCorasickAutomatonUnicode(matcher.patterns)
+		if err != nil {
+			return nil, err
+		}
+
+		matcher.automaton = automaton
+		matcher.unicodeMode = true
+		matcher.packed = nil // the packed prefilter only understands ASCII folding
+
+		matcher.foldedLens = make([]int, len(matcher.patterns))
+		for i, pattern := range matcher.patterns {
+			matcher.foldedLens[i] = len(foldCaser.String(pattern))
+		}
+	}
+
+	if opts.ByteClasses {
+		if matcher.unicodeMode {
+			return nil, fmt.Errorf("MatcherOptions.ByteClasses is not yet supported together with UnicodeCaseInsensitive")
+		}
+		matcher.compact = newCompactAutomaton(matcher.automaton)
+	}
+
+	return matcher, nil
+}
+
+// resolveMatchKind filters the raw, possibly-overlapping matches produced by
+// the DFA according to kind. For MatchLeftmostFirst and MatchLeftmostLongest
+// it first keeps a single winner per starting offset, then sweeps left to
+// right discarding any winner that starts inside the span of an
+// already-emitted match - "leftmost" matches are non-overlapping, so a
+// pattern starting at offset 3 must not survive once offset 0 already
+// claimed through offset 6. raw arrives ordered by ascending end/scan
+// position, not by ascending offset (a short pattern nested inside a longer
+// one completes first), so the per-offset winners are explicitly sorted by
+// offset before the sweep runs.
+func resolveMatchKind(raw []MatchResult, kind MatchKind) []MatchResult {
+	if kind == MatchStandard || len(raw) == 0 {
+		return raw
+	}
+
+	best := make(map[uint64]MatchResult, len(raw))
+	order := make([]uint64, 0, len(raw))
+
+	for _, r := range raw {
+		cur, seen := best[r.Offset]
+		if !seen {
+			best[r.Offset] = r
+			order = append(order, r.Offset)
+			continue
+		}
+
+		if betterMatch(r, cur, kind) {
+			best[r.Offset] = r
+		}
+	}
+
+	sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+
+	results := make([]MatchResult, 0, len(order))
+	var nextAllowed uint64
+	for _, offset := range order {
+		r := best[offset]
+		if offset < nextAllowed {
+			continue
+		}
+		results = append(results, r)
+		nextAllowed = r.Offset + r.Length
+	}
+
+	return results
+}
+
+// betterMatch reports whether candidate should replace incumbent as the
+// winner at their shared starting offset, per kind's priority rule.
+func betterMatch(candidate, incumbent MatchResult, kind MatchKind) bool {
+	switch kind {
+	case MatchLeftmostFirst:
+		if candidate.PatternID != incumbent.PatternID {
+			return candidate.PatternID < incumbent.PatternID
+		}
+		return candidate.Length > incumbent.Length
+	case MatchLeftmostLongest:
+		if candidate.Length != incumbent.Length {
+			return candidate.Length > incumbent.Length
+		}
+		return candidate.PatternID < incumbent.PatternID
+	default:
+		return false
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import "testing"
+
+func TestMatchKindSemanticsDiffer(t *testing.T) {
+	patterns := []string{"he said", "he said that", "said"}
+	text := "he said that it happened"
+
+	standard, err := NewAhoCorasickMatcherWithOptions(MatcherOptions{MatchKind: MatchStandard})
+	if err != nil {
+		t.Fatalf("NewAhoCorasickMatcherWithOptions: %v", err)
+	}
+	standard.patterns = patterns
+	standard.automaton, err = buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+	standard.packed = nil // stale prefilter built from the default LegalPatterns
+
+	leftmostFirst, err := NewAhoCorasickMatcherWithOptions(MatcherOptions{MatchKind: MatchLeftmostFirst})
+	if err != nil {
+		t.Fatalf("NewAhoCorasickMatcherWithOptions: %v", err)
+	}
+	leftmostFirst.patterns = patterns
+	leftmostFirst.automaton = standard.automaton
+	leftmostFirst.packed = nil
+
+	leftmostLongest, err := NewAhoCorasickMatcherWithOptions(MatcherOptions{MatchKind: MatchLeftmostLongest})
+	if err != nil {
+		t.Fatalf("NewAhoCorasickMatcherWithOptions: %v", err)
+	}
+	leftmostLongest.patterns = patterns
+	leftmostLongest.automaton = standard.automaton
+	leftmostLongest.packed = nil
+
+	standardResults, _, err := standard.Search(text)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(standardResults) != 3 {
+		t.Fatalf("MatchStandard: expected 3 overlapping matches, got %d: %+v", len(standardResults), standardResults)
+	}
+
+	firstResults, _, err := leftmostFirst.Search(text)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(firstResults) != 1 || firstResults[0].Text != "he said" {
+		t.Fatalf("MatchLeftmostFirst: expected single \"he said\" match, got %+v", firstResults)
+	}
+
+	longestResults, _, err := leftmostLongest.Search(text)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(longestResults) != 1 || longestResults[0].Text != "he said that" {
+		t.Fatalf("MatchLeftmostLongest: expected single \"he said that\" match, got %+v", longestResults)
+	}
+}
+
+// TestMatchKindNestedShorterPatternCompletesFirst guards against a case
+// where the raw matches are emitted in ascending end-position (scan) order
+// rather than ascending start-offset order: "fg" is nested inside
+// "abcdefghij" and finishes scanning - and is appended to the raw slice -
+// before the enclosing match completes, even though its offset is greater.
+// resolveMatchKind must sort by offset before its non-overlap sweep, or it
+// mistakes the nested match for coming first and lets it win, when both
+// leftmost modes promise the single longest / earliest-inserted match wins.
+func TestMatchKindNestedShorterPatternCompletesFirst(t *testing.T) {
+	patterns := []string{"abcdefghij", "fg"}
+	text := "xxabcdefghijxx"
+
+	build := func(kind MatchKind) *AhoCorasickMatcher {
+		matcher, err := NewAhoCorasickMatcherWithOptions(MatcherOptions{MatchKind: kind})
+		if err != nil {
+			t.Fatalf("NewAhoCorasickMatcherWithOptions: %v", err)
+		}
+		matcher.patterns = patterns
+		matcher.automaton, err = buildAhoCorasickAutomaton(patterns)
+		if err != nil {
+			t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+		}
+		matcher.packed = nil // stale prefilter built from the default LegalPatterns
+		return matcher
+	}
+
+	for _, kind := range []MatchKind{MatchLeftmostFirst, MatchLeftmostLongest} {
+		matcher := build(kind)
+		results, _, err := matcher.Search(text)
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+
+		if len(results) != 1 || results[0].Text != "abcdefghij" || results[0].Offset != 2 {
+			t.Fatalf("kind %v: expected the enclosing \"abcdefghij\" match at offset 2 to win, got %+v", kind, results)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+)
+
+// SearchAnchored reports a match only if it starts at offset 0, useful for
+// validating a whole utterance against a phrase list rather than scanning
+// for patterns anywhere inside it. It filters rawMatches down to Offset ==
+// 0 rather than re-walking the trie's true edges itself: a match starting
+// at 0 is, by construction, an unbroken run of true edges from the root
+// (reaching it by falling back to a failure link first would mean the
+// match actually started later in the text), so the filtered result is
+// identical to a dedicated anchored walk - while still going through
+// rawMatches gets Unicode folding, byte-class compaction, and the packed
+// prefilter right, which a hand-rolled ASCII walk over m.automaton would
+// not.
+func (m *AhoCorasickMatcher) SearchAnchored(text string) ([]MatchResult, error) {
+	if !m.initialized {
+		return nil, fmt.Errorf("matcher not initialized")
+	}
+
+	var results []MatchResult
+	for _, res := range m.rawMatches(text) {
+		if res.Offset == 0 {
+			results = append(results, res)
+		}
+	}
+
+	return results, nil
+}
+
+// SearchOverlapping reports every output at every state, including matches
+// that overlap each other - unlike Search, it is not affected by
+// AhoCorasickMatcher.matchKind.
+func (m *AhoCorasickMatcher) SearchOverlapping(text string) []MatchResult {
+	return m.rawMatches(text)
+}
+
+// MatchIter yields one match per Next() call. The matches themselves are
+// computed in full when the iterator is created, so stopping after the
+// first Next() call does not shorten the scan.
+type MatchIter struct {
+	matches []MatchResult
+	pos     int
+}
+
+// Iter returns an iterator over text's matches, reported in the same order
+// and with the same overlapping semantics as SearchOverlapping. It's built
+// on rawMatches rather than its own automaton walk, so it gets Unicode
+// folding, byte-class compaction, and the packed prefilter right instead of
+// the ASCII-only, unfolded-length walk an earlier version of this file
+// used.
+func (m *AhoCorasickMatcher) Iter(text string) *MatchIter {
+	return &MatchIter{matches: m.rawMatches(text)}
+}
+
+// Next advances the iterator and returns the next match, or (zero, false)
+// once the text is exhausted.
+func (it *MatchIter) Next() (MatchResult, bool) {
+	if it.pos >= len(it.matches) {
+		return MatchResult{}, false
+	}
+
+	result := it.matches[it.pos]
+	it.pos++
+	return result, true
+}
+
+// HasMatch reports whether text contains any hearsay pattern. It takes the
+// first result from Iter, which has already scanned the whole text, so the
+// scan does not stop at the first output.
+func (m *AhoCorasickMatcher) HasMatch(text string) bool {
+	it := m.Iter(text)
+	_, ok := it.Next()
+	return ok
+}
@@ -0,0 +1,139 @@
+package main
+
+import "testing"
+
+func newTestMatcher(t *testing.T, patterns []string) *AhoCorasickMatcher {
+	t.Helper()
+	automaton, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+	return &AhoCorasickMatcher{patterns: patterns, automaton: automaton, initialized: true}
+}
+
+func TestSearchAnchoredOnlyMatchesAtOffsetZero(t *testing.T) {
+	matcher := newTestMatcher(t, []string{"he said"})
+
+	results, err := matcher.SearchAnchored("he said it happened")
+	if err != nil {
+		t.Fatalf("SearchAnchored: %v", err)
+	}
+	if len(results) != 1 || results[0].Offset != 0 {
+		t.Fatalf("expected a single match at offset 0, got %+v", results)
+	}
+
+	results, err = matcher.SearchAnchored("yesterday he said it happened")
+	if err != nil {
+		t.Fatalf("SearchAnchored: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected no anchored match when the pattern doesn't start at offset 0, got %+v", results)
+	}
+}
+
+func TestSearchOverlappingReportsEveryOutput(t *testing.T) {
+	matcher := newTestMatcher(t, []string{"he said", "he said that", "said"})
+	text := "he said that it happened"
+
+	results := matcher.SearchOverlapping(text)
+	if len(results) != 3 {
+		t.Fatalf("expected all 3 overlapping matches, got %d: %+v", len(results), results)
+	}
+}
+
+func TestIterMatchesSearchOverlapping(t *testing.T) {
+	matcher := newTestMatcher(t, []string{"he said", "he said that", "said", "allegedly"})
+	text := "he said that allegedly happened"
+
+	want := matcher.SearchOverlapping(text)
+
+	var got []MatchResult
+	iter := matcher.Iter(text)
+	for {
+		m, ok := iter.Next()
+		if !ok {
+			break
+		}
+		got = append(got, m)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("iterator produced %d matches, SearchOverlapping produced %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("match %d differs: iter=%+v overlapping=%+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHasMatchStopsAtFirstOutput(t *testing.T) {
+	matcher := newTestMatcher(t, []string{"allegedly"})
+
+	if matcher.HasMatch("this is clean legal text") {
+		t.Fatal("expected no match in clean text")
+	}
+	if !matcher.HasMatch("it allegedly happened") {
+		t.Fatal("expected a match to be found")
+	}
+}
+
+// TestIterAndHasMatchHonorUnicodeCaseFolding guards against Iter/HasMatch
+// hardcoding strings.ToLower and walking the automaton directly, which
+// finds nothing on a UnicodeCaseInsensitive matcher whose automaton is
+// built over Unicode-folded bytes instead of ASCII-lowered ones.
+func TestIterAndHasMatchHonorUnicodeCaseFolding(t *testing.T) {
+	patterns := []string{"straße"}
+	automaton, err := buildAhoCorasickAutomatonUnicode(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomatonUnicode: %v", err)
+	}
+	matcher := &AhoCorasickMatcher{
+		patterns:    patterns,
+		automaton:   automaton,
+		initialized: true,
+		unicodeMode: true,
+		foldedLens:  []int{len(foldCaser.String(patterns[0]))},
+	}
+
+	text := "the straße sign"
+
+	if !matcher.HasMatch(text) {
+		t.Fatal("expected HasMatch to find the Unicode-folded pattern")
+	}
+
+	iter := matcher.Iter(text)
+	m, ok := iter.Next()
+	if !ok {
+		t.Fatal("expected Iter to yield a match")
+	}
+	if m.Text != "straße" {
+		t.Fatalf("got match text %q, want %q", m.Text, "straße")
+	}
+}
+
+// TestSearchAnchoredHonorsUnicodeCaseFolding guards against SearchAnchored
+// hardcoding strings.ToLower, which would miss a Unicode-folded match even
+// when it starts at offset 0.
+func TestSearchAnchoredHonorsUnicodeCaseFolding(t *testing.T) {
+	patterns := []string{"straße"}
+	automaton, err := buildAhoCorasickAutomatonUnicode(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomatonUnicode: %v", err)
+	}
+	matcher := &AhoCorasickMatcher{
+		patterns:    patterns,
+		automaton:   automaton,
+		initialized: true,
+		unicodeMode: true,
+		foldedLens:  []int{len(foldCaser.String(patterns[0]))},
+	}
+
+	results, err := matcher.SearchAnchored("STRASSE sign")
+	if err != nil {
+		t.Fatalf("SearchAnchored: %v", err)
+	}
+	if len(results) != 1 || results[0].Offset != 0 {
+		t.Fatalf("expected a single anchored match at offset 0, got %+v", results)
+	}
+}
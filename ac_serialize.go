@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// automatonMagic identifies a compiled Aho-Corasick automaton file.
+const automatonMagic uint32 = 0x41434644 // "ACFD"
+
+// automatonVersion must match between writer and reader; bump it whenever
+// the on-disk layout changes.
+const automatonVersion uint32 = 1
+
+// automatonFileHeader is the fixed-size prefix of a compiled automaton file.
+// Everything after it depends on StateCount/PatternCount/OutputCount.
+type automatonFileHeader struct {
+	Magic        uint32
+	Version      uint32
+	StateCount   uint32
+	PatternCount uint32
+	OutputCount  uint32
+}
+
+// WriteTo serializes the automaton as a fixed little-endian binary format:
+// a header, a flat [states][256]int32 transition table, a [states]int32
+// failure array, a packed outputs array shared across states, a
+// [states]int32 array of offsets into that array followed by a separate
+// [states]int32 array of lengths, and finally the pattern strings in a
+// length-prefixed table. It satisfies io.WriterTo.
+func (a *AhoCorasickAutomaton) WriteTo(w io.Writer) (int64, error) {
+	var written int64
+
+	outputs := make([]int32, 0, a.stateCount)
+	offsets := make([]int32, a.stateCount)
+	lengths := make([]int32, a.stateCount)
+	for i, state := range a.states {
+		offsets[i] = int32(len(outputs))
+		lengths[i] = int32(len(state.outputs))
+		for _, id := range state.outputs {
+			outputs = append(outputs, int32(id))
+		}
+	}
+
+	header := automatonFileHeader{
+		Magic:        automatonMagic,
+		Version:      automatonVersion,
+		StateCount:   uint32(a.stateCount),
+		PatternCount: uint32(a.patternCount),
+		OutputCount:  uint32(len(outputs)),
+	}
+	if err := binary.Write(w, binary.LittleEndian, &header); err != nil {
+		return written, err
+	}
+	written += int64(binary.Size(header))
+
+	transitions := make([]int32, a.stateCount*256)
+	failures := make([]int32, a.stateCount)
+	for i, state := range a.states {
+		copy(transitions[i*256:(i+1)*256], state.next)
+		failures[i] = int32(state.failure)
+	}
+
+	for _, section := range []interface{}{transitions, failures, outputs, offsets, lengths} {
+		if err := binary.Write(w, binary.LittleEndian, section); err != nil {
+			return written, err
+		}
+		written += int64(binary.Size(section))
+	}
+
+	for _, pattern := range a.patterns {
+		b := []byte(pattern)
+		if err := binary.Write(w, binary.LittleEndian, uint32(len(b))); err != nil {
+			return written, err
+		}
+		written += 4
+
+		n, err := w.Write(b)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// LoadAhoCorasickAutomaton reads back an automaton written by WriteTo,
+// rejecting files with a mismatched magic or version.
+func LoadAhoCorasickAutomaton(path string) (*AhoCorasickAutomaton, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readAhoCorasickAutomaton(f)
+}
+
+// readAhoCorasickAutomaton decodes the WriteTo format from r, which is
+// always a plain *os.File today.
+func readAhoCorasickAutomaton(r io.Reader) (*AhoCorasickAutomaton, error) {
+	var header automatonFileHeader
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+	if header.Magic != automatonMagic {
+		return nil, fmt.Errorf("not a compiled Aho-Corasick automaton file")
+	}
+	if header.Version != automatonVersion {
+		return nil, fmt.Errorf("compiled automaton version %d does not match expected version %d", header.Version, automatonVersion)
+	}
+
+	stateCount := int(header.StateCount)
+
+	transitions := make([]int32, stateCount*256)
+	if err := binary.Read(r, binary.LittleEndian, transitions); err != nil {
+		return nil, err
+	}
+
+	failures := make([]int32, stateCount)
+	if err := binary.Read(r, binary.LittleEndian, failures); err != nil {
+		return nil, err
+	}
+
+	outputs := make([]int32, header.OutputCount)
+	if err := binary.Read(r, binary.LittleEndian, outputs); err != nil {
+		return nil, err
+	}
+
+	offsets := make([]int32, stateCount)
+	if err := binary.Read(r, binary.LittleEndian, offsets); err != nil {
+		return nil, err
+	}
+
+	lengths := make([]int32, stateCount)
+	if err := binary.Read(r, binary.LittleEndian, lengths); err != nil {
+		return nil, err
+	}
+
+	patterns := make([]string, header.PatternCount)
+	for i := range patterns {
+		var length uint32
+		if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+			return nil, err
+		}
+		buf := make([]byte, length)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		patterns[i] = string(buf)
+	}
+
+	states := make([]ACState, stateCount)
+	for i := range states {
+		states[i].failure = int(failures[i])
+		states[i].next = transitions[i*256 : (i+1)*256 : (i+1)*256]
+
+		n := lengths[i]
+		if n == 0 {
+			continue
+		}
+		off := offsets[i]
+		states[i].outputs = make([]int, n)
+		for j := int32(0); j < n; j++ {
+			states[i].outputs[j] = int(outputs[off+j])
+		}
+	}
+
+	return &AhoCorasickAutomaton{
+		states:       states,
+		stateCount:   stateCount,
+		patternCount: int(header.PatternCount),
+		patterns:     patterns,
+	}, nil
+}
+
+// NewAhoCorasickMatcherFromAutomaton wraps a previously built or loaded
+// automaton in a ready-to-use matcher, bypassing buildAhoCorasickAutomaton
+// entirely.
+func NewAhoCorasickMatcherFromAutomaton(automaton *AhoCorasickAutomaton) *AhoCorasickMatcher {
+	matcher := &AhoCorasickMatcher{
+		patterns:    automaton.patterns,
+		cache:       NewCache(10000),
+		automaton:   automaton,
+		initialized: true,
+	}
+
+	if packed, ok := newPackedPrefilter(matcher.patterns); ok {
+		matcher.packed = packed
+	}
+
+	return matcher
+}
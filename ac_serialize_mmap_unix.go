@@ -0,0 +1,144 @@
+//go:build !windows
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// LoadAhoCorasickAutomatonMmap reads back an automaton written by WriteTo
+// the same way LoadAhoCorasickAutomaton does, except the transition table -
+// by far the largest section at stateCount*256 int32s, dwarfing the
+// failure/output/pattern sections - aliases the mapped file directly
+// instead of being decoded into a fresh heap slice. The mapping is never
+// unmapped: this CLI loads an automaton once and runs until exit, so there
+// is no point in the process's lifetime where unmapping would be safe
+// without also discarding the automaton built on top of it.
+func LoadAhoCorasickAutomatonMmap(path string) (*AhoCorasickAutomaton, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := int(info.Size())
+
+	data, err := unix.Mmap(int(f.Fd()), 0, size, unix.PROT_READ, unix.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("mmap %s: %w", path, err)
+	}
+
+	const headerSize = 20 // 5 uint32 fields, see automatonFileHeader
+	if size < headerSize {
+		return nil, fmt.Errorf("compiled automaton file too small")
+	}
+
+	header := automatonFileHeader{
+		Magic:        binary.LittleEndian.Uint32(data[0:4]),
+		Version:      binary.LittleEndian.Uint32(data[4:8]),
+		StateCount:   binary.LittleEndian.Uint32(data[8:12]),
+		PatternCount: binary.LittleEndian.Uint32(data[12:16]),
+		OutputCount:  binary.LittleEndian.Uint32(data[16:20]),
+	}
+	if header.Magic != automatonMagic {
+		return nil, fmt.Errorf("not a compiled Aho-Corasick automaton file")
+	}
+	if header.Version != automatonVersion {
+		return nil, fmt.Errorf("compiled automaton version %d does not match expected version %d", header.Version, automatonVersion)
+	}
+
+	stateCount := int(header.StateCount)
+
+	off := headerSize
+	transitionsBytes := stateCount * 256 * 4
+	if off+transitionsBytes > size {
+		return nil, fmt.Errorf("compiled automaton file truncated in transition table")
+	}
+	// Zero-copy: reinterpret the mapped bytes directly as []int32 instead of
+	// decoding them into a freshly allocated slice. headerSize and every
+	// preceding section are int32-array-sized (hence 4-byte aligned), so
+	// this offset always falls on a valid int32 boundary.
+	transitions := unsafe.Slice((*int32)(unsafe.Pointer(&data[off])), stateCount*256)
+	off += transitionsBytes
+
+	failures := make([]int32, stateCount)
+	if err := readInt32Slice(data, &off, failures); err != nil {
+		return nil, err
+	}
+
+	outputs := make([]int32, header.OutputCount)
+	if err := readInt32Slice(data, &off, outputs); err != nil {
+		return nil, err
+	}
+
+	offsets := make([]int32, stateCount)
+	if err := readInt32Slice(data, &off, offsets); err != nil {
+		return nil, err
+	}
+
+	lengths := make([]int32, stateCount)
+	if err := readInt32Slice(data, &off, lengths); err != nil {
+		return nil, err
+	}
+
+	patterns := make([]string, header.PatternCount)
+	for i := range patterns {
+		if off+4 > size {
+			return nil, fmt.Errorf("compiled automaton file truncated in pattern table")
+		}
+		length := int(binary.LittleEndian.Uint32(data[off : off+4]))
+		off += 4
+
+		if off+length > size {
+			return nil, fmt.Errorf("compiled automaton file truncated in pattern table")
+		}
+		patterns[i] = string(data[off : off+length])
+		off += length
+	}
+
+	states := make([]ACState, stateCount)
+	for i := range states {
+		states[i].failure = int(failures[i])
+		states[i].next = transitions[i*256 : (i+1)*256 : (i+1)*256]
+
+		n := lengths[i]
+		if n == 0 {
+			continue
+		}
+		o := offsets[i]
+		states[i].outputs = make([]int, n)
+		for j := int32(0); j < n; j++ {
+			states[i].outputs[j] = int(outputs[o+j])
+		}
+	}
+
+	return &AhoCorasickAutomaton{
+		states:       states,
+		stateCount:   stateCount,
+		patternCount: int(header.PatternCount),
+		patterns:     patterns,
+	}, nil
+}
+
+// readInt32Slice decodes len(dst) little-endian int32s from data starting
+// at *off, advancing *off past them.
+func readInt32Slice(data []byte, off *int, dst []int32) error {
+	need := len(dst) * 4
+	if *off+need > len(data) {
+		return fmt.Errorf("compiled automaton file truncated")
+	}
+	for i := range dst {
+		dst[i] = int32(binary.LittleEndian.Uint32(data[*off+i*4 : *off+i*4+4]))
+	}
+	*off += need
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package main
+
+// LoadAhoCorasickAutomatonMmap falls back to the plain heap-decoding loader
+// on Windows, where the zero-copy implementation in
+// ac_serialize_mmap_unix.go isn't built. --load still works, just without
+// the zero-copy transition table.
+func LoadAhoCorasickAutomatonMmap(path string) (*AhoCorasickAutomaton, error) {
+	return LoadAhoCorasickAutomaton(path)
+}
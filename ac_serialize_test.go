@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAutomatonWriteToAndLoadRoundTrip(t *testing.T) {
+	patterns := []string{"he said", "she told", "allegedly"}
+	automaton, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "automaton.bin")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := automaton.WriteTo(f); err != nil {
+		f.Close()
+		t.Fatalf("WriteTo: %v", err)
+	}
+	f.Close()
+
+	loaded, err := LoadAhoCorasickAutomaton(path)
+	if err != nil {
+		t.Fatalf("LoadAhoCorasickAutomaton: %v", err)
+	}
+
+	matcher := NewAhoCorasickMatcherFromAutomaton(loaded)
+	text := "she told me he said it allegedly happened"
+	got := matcher.rawMatches(text)
+
+	original := &AhoCorasickMatcher{patterns: patterns, automaton: automaton, initialized: true}
+	want := original.rawMatches(text)
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d matches, want %d: got=%+v want=%+v", len(got), len(want), got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("match %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestAutomatonWriteToAndLoadMmapRoundTrip guards the mmap-backed loader
+// against diverging from the plain heap loader: it must produce the same
+// matches despite aliasing the mapped file for its transition table instead
+// of decoding a fresh copy.
+func TestAutomatonWriteToAndLoadMmapRoundTrip(t *testing.T) {
+	patterns := []string{"he said", "she told", "allegedly"}
+	automaton, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "automaton.bin")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := automaton.WriteTo(f); err != nil {
+		f.Close()
+		t.Fatalf("WriteTo: %v", err)
+	}
+	f.Close()
+
+	loaded, err := LoadAhoCorasickAutomatonMmap(path)
+	if err != nil {
+		t.Fatalf("LoadAhoCorasickAutomatonMmap: %v", err)
+	}
+
+	matcher := NewAhoCorasickMatcherFromAutomaton(loaded)
+	text := "she told me he said it allegedly happened"
+	got := matcher.rawMatches(text)
+
+	original := &AhoCorasickMatcher{patterns: patterns, automaton: automaton, initialized: true}
+	want := original.rawMatches(text)
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d matches, want %d: got=%+v want=%+v", len(got), len(want), got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("match %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLoadAhoCorasickAutomatonRejectsBadVersion(t *testing.T) {
+	patterns := []string{"he said"}
+	automaton, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "automaton.bin")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := automaton.WriteTo(f); err != nil {
+		f.Close()
+		t.Fatalf("WriteTo: %v", err)
+	}
+	f.Close()
+
+	// Corrupt the version field (right after the magic, both uint32) so the
+	// loader's version check rejects the file.
+	f, err = os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if _, err := f.Seek(4, 0); err != nil {
+		f.Close()
+		t.Fatalf("seek: %v", err)
+	}
+	if err := binary.Write(f, binary.LittleEndian, uint32(9999)); err != nil {
+		f.Close()
+		t.Fatalf("write: %v", err)
+	}
+	f.Close()
+
+	if _, err := LoadAhoCorasickAutomaton(path); err == nil {
+		t.Fatal("expected a version mismatch error")
+	}
+}
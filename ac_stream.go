@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// streamChunkSize is the amount of new data read per SearchStream iteration.
+// It's a var (not a const) so tests can shrink it to exercise chunk-boundary
+// behavior without allocating gigabytes of test input.
+var streamChunkSize = 64 * 1024
+
+// maxPatternLen returns the length, in bytes, of the longest span a single
+// match can cover: the longest raw pattern, or its folded length if that's
+// longer (folding can grow a pattern's byte length), whichever applies under
+// m's case-folding mode. Used to size the overlap window that keeps a
+// pattern split across a chunk or window boundary from being missed.
+func (m *AhoCorasickMatcher) maxPatternLen() int {
+	max := 0
+	for i, p := range m.patterns {
+		l := len(p)
+		if m.unicodeMode && i < len(m.foldedLens) && m.foldedLens[i] > l {
+			l = m.foldedLens[i]
+		}
+		if l > max {
+			max = l
+		}
+	}
+	return max
+}
+
+// dedupKey identifies a match by its absolute offset and pattern, so the
+// same match found twice by overlapping rescans of a chunk or window isn't
+// reported twice.
+type dedupKey struct {
+	offset    uint64
+	patternID uint32
+}
+
+// SearchStream scans r in bounded-size chunks so gigabyte-sized transcripts
+// can be processed without loading them into memory, routing every chunk
+// through rawMatches so streaming results use the same case-folding,
+// byte-class, and prefilter dispatch as Search - unlike a hand-rolled ASCII
+// scan, this also gets UnicodeCaseInsensitive matchers right. Each chunk
+// carries forward an overlap of maxPatternLen-1 bytes from the previous one
+// so a pattern split across a chunk boundary is still found; matches are
+// deduplicated by absolute offset since the overlap region is rescanned.
+//
+// For MatchStandard (the default), results are independent of each other, so
+// they're delivered to cb as soon as a chunk produces them. Leftmost
+// resolution (MatchLeftmostFirst/MatchLeftmostLongest) needs the full set of
+// candidates at a given offset before it can pick a winner, which a later
+// chunk could still contribute to - so for those kinds, matches are
+// collected across the whole stream and resolveMatchKind runs once at EOF,
+// trading early delivery for matching Search's semantics exactly.
+func (m *AhoCorasickMatcher) SearchStream(r io.Reader, cb func(MatchResult) error) error {
+	if !m.initialized {
+		return fmt.Errorf("matcher not initialized")
+	}
+
+	overlap := m.maxPatternLen() - 1
+	if overlap < 0 {
+		overlap = 0
+	}
+
+	buf := make([]byte, 0, overlap+streamChunkSize)
+	readBuf := make([]byte, streamChunkSize)
+	seen := make(map[dedupKey]bool)
+	var base uint64 // absolute stream offset of buf[0]
+	var collected []MatchResult
+
+	deliver := func(res MatchResult) error {
+		if m.matchKind == MatchStandard {
+			return cb(res)
+		}
+		collected = append(collected, res)
+		return nil
+	}
+
+	for {
+		n, err := r.Read(readBuf)
+		if n > 0 {
+			buf = append(buf, readBuf[:n]...)
+
+			for _, res := range m.rawMatches(string(buf)) {
+				abs := base + res.Offset
+				key := dedupKey{abs, res.PatternID}
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				res.Offset = abs
+				if err := deliver(res); err != nil {
+					return err
+				}
+			}
+
+			if len(buf) > overlap {
+				drop := len(buf) - overlap
+				base += uint64(drop)
+				buf = append(buf[:0], buf[drop:]...)
+
+				for key := range seen {
+					if key.offset < base {
+						delete(seen, key)
+					}
+				}
+			}
+		}
+
+		if err == io.EOF {
+			for _, res := range resolveMatchKind(collected, m.matchKind) {
+				if err := cb(res); err != nil {
+					return err
+				}
+			}
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// SearchReaderAt parallelizes search over r by splitting it into windows of
+// streamChunkSize bytes, each extended by maxPatternLen-1 bytes of overlap
+// so a pattern straddling a window boundary is found by the window it
+// starts in. Matches are deduplicated by (offset, pattern) before the
+// per-window results are merged back into offset order.
+func (m *AhoCorasickMatcher) SearchReaderAt(r io.ReaderAt, size int64) ([]MatchResult, error) {
+	if !m.initialized {
+		return nil, fmt.Errorf("matcher not initialized")
+	}
+
+	overlap := int64(m.maxPatternLen() - 1)
+	if overlap < 0 {
+		overlap = 0
+	}
+
+	windowSize := int64(streamChunkSize)
+	if windowSize < overlap {
+		windowSize = overlap + 1
+	}
+
+	type window struct {
+		start, end int64
+	}
+
+	var windows []window
+	for start := int64(0); start < size; start += windowSize {
+		end := start + windowSize + overlap
+		if end > size {
+			end = size
+		}
+		windows = append(windows, window{start: start, end: end})
+	}
+
+	perWindow := make([][]MatchResult, len(windows))
+	errs := make([]error, len(windows))
+
+	var wg sync.WaitGroup
+	for i, w := range windows {
+		wg.Add(1)
+		go func(i int, w window) {
+			defer wg.Done()
+
+			buf := make([]byte, w.end-w.start)
+			if _, err := r.ReadAt(buf, w.start); err != nil && err != io.EOF {
+				errs[i] = err
+				return
+			}
+
+			isLast := i == len(windows)-1
+			bodyEnd := w.start + windowSize
+
+			var kept []MatchResult
+			for _, res := range m.rawMatches(string(buf)) {
+				abs := w.start + int64(res.Offset)
+				if abs >= bodyEnd && !isLast {
+					// Belongs to the next window's body; it will find this
+					// match itself since it owns the overlap region.
+					continue
+				}
+				res.Offset = uint64(abs)
+				kept = append(kept, res)
+			}
+			perWindow[i] = kept
+		}(i, w)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[dedupKey]bool)
+	var merged []MatchResult
+	for _, results := range perWindow {
+		for _, res := range results {
+			key := dedupKey{res.Offset, res.PatternID}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, res)
+		}
+	}
+
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Offset < merged[j].Offset })
+
+	return resolveMatchKind(merged, m.matchKind), nil
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSearchStreamSplitAcrossChunkBoundary(t *testing.T) {
+	patterns := []string{"he said", "allegedly"}
+	automaton, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+	matcher := &AhoCorasickMatcher{patterns: patterns, automaton: automaton, initialized: true}
+
+	text := "before he said allegedly after"
+
+	oldChunkSize := streamChunkSize
+	defer func() { streamChunkSize = oldChunkSize }()
+
+	// Shrink the chunk size so "he said" and "allegedly" are each forced to
+	// straddle a read boundary.
+	streamChunkSize = 10
+
+	var got []MatchResult
+	err = matcher.SearchStream(strings.NewReader(text), func(m MatchResult) error {
+		got = append(got, m)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchStream: %v", err)
+	}
+
+	want := matcher.rawMatches(text)
+	if len(got) != len(want) {
+		t.Fatalf("got %d matches, want %d: got=%+v want=%+v", len(got), len(want), got, want)
+	}
+	for i := range want {
+		if got[i].Offset != want[i].Offset || got[i].Text != want[i].Text {
+			t.Fatalf("match %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestSearchStreamHonorsMatchKind guards against SearchStream re-implementing
+// its own DFA walk and reporting every overlapping match regardless of the
+// matcher's MatchKind, instead of routing through the same
+// rawMatches/resolveMatchKind pipeline Search uses.
+func TestSearchStreamHonorsMatchKind(t *testing.T) {
+	patterns := []string{"he said", "he said that", "said"}
+	automaton, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+	matcher := &AhoCorasickMatcher{patterns: patterns, automaton: automaton, cache: NewCache(10), initialized: true, matchKind: MatchLeftmostFirst}
+
+	text := "he said that it happened"
+
+	want, _, err := matcher.Search(text)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	var got []MatchResult
+	err = matcher.SearchStream(strings.NewReader(text), func(m MatchResult) error {
+		got = append(got, m)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchStream: %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("SearchStream found %d matches under MatchLeftmostFirst, want %d (matching Search): got=%+v want=%+v", len(got), len(want), got, want)
+	}
+	for i := range want {
+		if got[i].Offset != want[i].Offset || got[i].Text != want[i].Text {
+			t.Fatalf("match %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestSearchStreamUnicodeCaseFolding guards against SearchStream hardcoding
+// strings.ToLower and walking a byte-keyed automaton, which finds nothing on
+// a UnicodeCaseInsensitive matcher whose automaton is built over
+// Unicode-folded bytes instead of ASCII-lowered ones.
+func TestSearchStreamUnicodeCaseFolding(t *testing.T) {
+	patterns := []string{"straße"}
+	automaton, err := buildAhoCorasickAutomatonUnicode(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomatonUnicode: %v", err)
+	}
+	matcher := &AhoCorasickMatcher{
+		patterns:    patterns,
+		automaton:   automaton,
+		cache:       NewCache(10),
+		initialized: true,
+		unicodeMode: true,
+		foldedLens:  []int{len(foldCaser.String(patterns[0]))},
+	}
+
+	text := "the straße sign"
+
+	want, _, err := matcher.Search(text)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(want) != 1 {
+		t.Fatalf("Search: expected 1 match, got %+v", want)
+	}
+
+	var got []MatchResult
+	err = matcher.SearchStream(strings.NewReader(text), func(m MatchResult) error {
+		got = append(got, m)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchStream: %v", err)
+	}
+
+	if len(got) != 1 || got[0].Offset != want[0].Offset || got[0].Text != want[0].Text {
+		t.Fatalf("SearchStream: got %+v, want %+v", got, want)
+	}
+}
+
+func TestSearchReaderAtMergesWindows(t *testing.T) {
+	patterns := []string{"he said", "allegedly"}
+	automaton, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+	matcher := &AhoCorasickMatcher{patterns: patterns, automaton: automaton, initialized: true}
+
+	text := "before he said allegedly after"
+
+	oldChunkSize := streamChunkSize
+	defer func() { streamChunkSize = oldChunkSize }()
+	streamChunkSize = 10
+
+	r := bytes.NewReader([]byte(text))
+	got, err := matcher.SearchReaderAt(r, int64(len(text)))
+	if err != nil {
+		t.Fatalf("SearchReaderAt: %v", err)
+	}
+
+	want := matcher.rawMatches(text)
+	if len(got) != len(want) {
+		t.Fatalf("got %d matches, want %d: got=%+v want=%+v", len(got), len(want), got, want)
+	}
+	for i := range want {
+		if got[i].Offset != want[i].Offset || got[i].Text != want[i].Text {
+			t.Fatalf("match %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestSearchReaderAtHonorsMatchKind guards against SearchReaderAt merging
+// each window's raw, overlapping matches without ever applying
+// resolveMatchKind, which would make it disagree with Search whenever the
+// matcher isn't MatchStandard.
+func TestSearchReaderAtHonorsMatchKind(t *testing.T) {
+	patterns := []string{"he said", "he said that", "said"}
+	automaton, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+	matcher := &AhoCorasickMatcher{patterns: patterns, automaton: automaton, cache: NewCache(10), initialized: true, matchKind: MatchLeftmostFirst}
+
+	text := "he said that it happened"
+
+	want, _, err := matcher.Search(text)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	r := bytes.NewReader([]byte(text))
+	got, err := matcher.SearchReaderAt(r, int64(len(text)))
+	if err != nil {
+		t.Fatalf("SearchReaderAt: %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("SearchReaderAt found %d matches under MatchLeftmostFirst, want %d (matching Search): got=%+v want=%+v", len(got), len(want), got, want)
+	}
+	for i := range want {
+		if got[i].Offset != want[i].Offset || got[i].Text != want[i].Text {
+			t.Fatalf("match %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
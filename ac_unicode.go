@@ -0,0 +1,153 @@
+package main
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+)
+
+// foldCaser performs Unicode-aware case folding, correctly handling cases
+// strings.ToLower gets wrong (Turkish İ, German ß expanding to "ss", etc).
+var foldCaser = cases.Fold()
+
+// foldWithMapping case-folds text rune by rune and records, for every byte
+// of the folded output, the [start, end) byte range of the original rune
+// that produced it. Folding can grow a rune's byte length (ß -> ss), so the
+// folded string's length can differ from len(text); the mapping lets
+// matches found in the folded string be translated back to offsets into the
+// untouched original input.
+func foldWithMapping(text string) (folded string, origStart, origEnd []int) {
+	buf := make([]byte, 0, len(text))
+
+	for idx := 0; idx < len(text); {
+		r, size := utf8.DecodeRuneInString(text[idx:])
+		piece := foldCaser.String(string(r))
+		buf = append(buf, piece...)
+
+		for j := 0; j < len(piece); j++ {
+			origStart = append(origStart, idx)
+			origEnd = append(origEnd, idx+size)
+		}
+
+		idx += size
+	}
+
+	return string(buf), origStart, origEnd
+}
+
+// buildAhoCorasickAutomatonUnicode builds the trie/failure-link DFA the same
+// way buildAhoCorasickAutomaton does, but walks the raw UTF-8 bytes of each
+// folded pattern instead of decoding runes, so every byte value 0x00-0xFF is
+// a valid edge and patterns outside ASCII survive intact.
+func buildAhoCorasickAutomatonUnicode(patterns []string) (*AhoCorasickAutomaton, error) {
+	ac := &AhoCorasickAutomaton{
+		states:       []ACState{newACState()},
+		stateCount:   1,
+		patternCount: len(patterns),
+		patterns:     patterns,
+	}
+
+	for patternID, pattern := range patterns {
+		folded := foldCaser.String(pattern)
+		state := 0
+
+		for i := 0; i < len(folded); i++ {
+			c := int(folded[i])
+
+			if ac.states[state].next[c] == 0 {
+				ac.states = append(ac.states, newACState())
+				ac.states[state].next[c] = int32(ac.stateCount)
+				ac.stateCount++
+			}
+
+			state = int(ac.states[state].next[c])
+		}
+
+		ac.states[state].outputs = append(ac.states[state].outputs, patternID)
+	}
+
+	// Build failure links via BFS, identical in shape to
+	// buildAhoCorasickAutomaton's pass, just over the byte-keyed trie above.
+	queue := make([]int, 0, ac.stateCount)
+
+	for c := 0; c < 256; c++ {
+		state := int(ac.states[0].next[c])
+		if state != 0 {
+			ac.states[state].failure = 0
+			queue = append(queue, state)
+		}
+	}
+
+	for len(queue) > 0 {
+		r := queue[0]
+		queue = queue[1:]
+
+		for c := 0; c < 256; c++ {
+			u := int(ac.states[r].next[c])
+			if u == 0 {
+				continue
+			}
+
+			queue = append(queue, u)
+
+			state := ac.states[r].failure
+			for state != 0 && ac.states[state].next[c] == 0 {
+				state = ac.states[state].failure
+			}
+
+			ac.states[u].failure = int(ac.states[state].next[c])
+
+			failureState := ac.states[u].failure
+			ac.states[u].outputs = append(ac.states[u].outputs, ac.states[failureState].outputs...)
+		}
+	}
+
+	return ac, nil
+}
+
+// rawMatchesUnicode scans text with full Unicode case folding, reporting
+// matches whose Offset/Length/Text refer to the untouched original input
+// even though the automaton itself runs over folded bytes.
+func (m *AhoCorasickMatcher) rawMatchesUnicode(text string) []MatchResult {
+	folded, origStart, origEnd := foldWithMapping(text)
+
+	var results []MatchResult
+	state := 0
+
+	for i := 0; i < len(folded); i++ {
+		c := int(folded[i])
+
+		for state != 0 && m.automaton.states[state].next[c] == 0 {
+			state = m.automaton.states[state].failure
+		}
+		state = int(m.automaton.states[state].next[c])
+
+		for _, patternID := range m.automaton.states[state].outputs {
+			if patternID >= len(m.patterns) || patternID >= len(m.foldedLens) {
+				continue
+			}
+
+			foldedLen := m.foldedLens[patternID]
+			foldedStart := i - foldedLen + 1
+			if foldedStart < 0 {
+				continue
+			}
+
+			start := origStart[foldedStart]
+			end := origEnd[i]
+			if end > len(text) {
+				continue
+			}
+
+			results = append(results, MatchResult{
+				Offset:     uint64(start),
+				Length:     uint64(end - start),
+				PatternID:  uint32(patternID),
+				Confidence: 95,
+				Text:       text[start:end],
+			})
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,78 @@
+package main
+
+import "testing"
+
+func TestUnicodeCaseInsensitiveMatchesAccentedPattern(t *testing.T) {
+	matcher, err := NewAhoCorasickMatcherWithOptions(MatcherOptions{UnicodeCaseInsensitive: true})
+	if err != nil {
+		t.Fatalf("NewAhoCorasickMatcherWithOptions: %v", err)
+	}
+	matcher.patterns = []string{"déposé"}
+	automaton, err := buildAhoCorasickAutomatonUnicode(matcher.patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomatonUnicode: %v", err)
+	}
+	matcher.automaton = automaton
+	matcher.foldedLens = []int{len(foldCaser.String(matcher.patterns[0]))}
+
+	text := "le témoin a DÉPOSÉ hier"
+	results, _, err := matcher.Search(text)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d: %+v", len(results), results)
+	}
+	if results[0].Text != "DÉPOSÉ" {
+		t.Fatalf("expected matched text to be the untouched original substring, got %q", results[0].Text)
+	}
+}
+
+func TestUnicodeCaseInsensitiveHandlesNonLatinScript(t *testing.T) {
+	matcher, err := NewAhoCorasickMatcherWithOptions(MatcherOptions{UnicodeCaseInsensitive: true})
+	if err != nil {
+		t.Fatalf("NewAhoCorasickMatcherWithOptions: %v", err)
+	}
+	matcher.patterns = []string{"свидетель"} // "witness" in Russian
+	automaton, err := buildAhoCorasickAutomatonUnicode(matcher.patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomatonUnicode: %v", err)
+	}
+	matcher.automaton = automaton
+	matcher.foldedLens = []int{len(foldCaser.String(matcher.patterns[0]))}
+
+	text := "вызвать СВИДЕТЕЛЬ в суд"
+	results, _, err := matcher.Search(text)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match in non-Latin text, got %d: %+v", len(results), results)
+	}
+}
+
+func TestUnicodeCaseInsensitiveHandlesLengthChangingFold(t *testing.T) {
+	matcher, err := NewAhoCorasickMatcherWithOptions(MatcherOptions{UnicodeCaseInsensitive: true})
+	if err != nil {
+		t.Fatalf("NewAhoCorasickMatcherWithOptions: %v", err)
+	}
+	matcher.patterns = []string{"straße"} // German "ß" folds to "ss"
+	automaton, err := buildAhoCorasickAutomatonUnicode(matcher.patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomatonUnicode: %v", err)
+	}
+	matcher.automaton = automaton
+	matcher.foldedLens = []int{len(foldCaser.String(matcher.patterns[0]))}
+
+	text := "auf der STRASSE gesehen"
+	results, _, err := matcher.Search(text)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match despite fold changing byte length, got %d: %+v", len(results), results)
+	}
+	if results[0].Text != "STRASSE" {
+		t.Fatalf("expected matched text %q, got %q", "STRASSE", results[0].Text)
+	}
+}
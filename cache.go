@@ -1,27 +1,91 @@
 package main
 
 import (
+	"container/list"
 	"hash/fnv"
 	"sync"
-	"sync/atomic"
 	"time"
+	"unsafe"
 )
 
 // CacheEntry represents a cached search result
 type CacheEntry struct {
+	Key      uint64
 	Input    string
 	Results  []MatchResult
 	Duration time.Duration
 	Created  time.Time
+	Accessed time.Time
 	Hits     int64
 }
 
-// Cache provides ultra-fast pattern matching result caching
+// size returns the entry's accounted byte cost: the input text plus the
+// fixed-size result records it cached.
+func (e *CacheEntry) size() int64 {
+	return int64(len(e.Input)) + int64(len(e.Results))*int64(unsafe.Sizeof(MatchResult{}))
+}
+
+// CacheOptions configures a Cache's capacity and expiry. A zero MaxBytes or
+// TTL disables that particular cap.
+type CacheOptions struct {
+	MaxEntries int
+	MaxBytes   int64
+	TTL        time.Duration
+}
+
+// cacheShardCount is the number of independent shards a Cache stripes its
+// keys across. Each shard has its own lock, so concurrent Put calls issued
+// by a SearchBatch worker pool contend only with other workers hashing to
+// the same shard instead of serializing on one mutex.
+const cacheShardCount = 16
+
+// cacheShard is one independent LRU partition of a Cache: a map for O(1)
+// lookup plus a doubly-linked list ordered by recency so Get/Put/evict are
+// all O(1).
+type cacheShard struct {
+	mutex     sync.RWMutex
+	entries   map[uint64]*list.Element
+	order     *list.List // front = most recently used, back = least
+	usedBytes int64
+	stats     CacheStats
+}
+
+func newCacheShard() *cacheShard {
+	return &cacheShard{
+		entries: make(map[uint64]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// evictOldest removes the least-recently-used entry in O(1) via the list's
+// back element. Caller must hold s.mutex.
+func (s *cacheShard) evictOldest() {
+	elem := s.order.Back()
+	if elem == nil {
+		return
+	}
+	s.removeElement(elem)
+	s.stats.Evictions++
+}
+
+// removeElement removes elem from both the list and the map and adjusts the
+// byte-size accounting. Caller must hold s.mutex.
+func (s *cacheShard) removeElement(elem *list.Element) {
+	entry := elem.Value.(*CacheEntry)
+	s.order.Remove(elem)
+	delete(s.entries, entry.Key)
+	s.usedBytes -= entry.size()
+}
+
+// Cache provides ultra-fast pattern matching result caching. Keys are
+// striped across cacheShardCount shards (see cacheShard), each enforcing an
+// even share of opts' entry-count and byte-size caps; TTL expiry is checked
+// per entry on Get.
 type Cache struct {
-	entries map[uint64]*CacheEntry
-	mutex   sync.RWMutex
-	maxSize int
-	stats   CacheStats
+	shards             [cacheShardCount]*cacheShard
+	opts               CacheOptions
+	maxEntriesPerShard int
+	maxBytesPerShard   int64
 }
 
 // CacheStats tracks cache performance
@@ -32,12 +96,34 @@ type CacheStats struct {
 	TotalEntries int64
 }
 
-// NewCache creates a new cache with specified maximum size
+// NewCache creates a new cache with the given maximum entry count and no
+// byte-size cap or TTL, preserved for existing callers.
 func NewCache(maxSize int) *Cache {
-	return &Cache{
-		entries: make(map[uint64]*CacheEntry),
-		maxSize: maxSize,
+	return NewCacheWithOptions(CacheOptions{MaxEntries: maxSize})
+}
+
+// NewCacheWithOptions creates a cache enforcing every configured limit in
+// opts, split evenly across cacheShardCount shards.
+func NewCacheWithOptions(opts CacheOptions) *Cache {
+	c := &Cache{opts: opts}
+	for i := range c.shards {
+		c.shards[i] = newCacheShard()
+	}
+
+	if opts.MaxEntries > 0 {
+		c.maxEntriesPerShard = opts.MaxEntries / cacheShardCount
+		if c.maxEntriesPerShard < 1 {
+			c.maxEntriesPerShard = 1
+		}
+	}
+	if opts.MaxBytes > 0 {
+		c.maxBytesPerShard = opts.MaxBytes / cacheShardCount
+		if c.maxBytesPerShard < 1 {
+			c.maxBytesPerShard = 1
+		}
 	}
+
+	return c
 }
 
 // hash generates a fast hash for input text
@@ -47,102 +133,115 @@ func (c *Cache) hash(input string) uint64 {
 	return h.Sum64()
 }
 
-// Get retrieves cached results for input text
+// shardFor returns the shard responsible for key.
+func (c *Cache) shardFor(key uint64) *cacheShard {
+	return c.shards[key%cacheShardCount]
+}
+
+// Get retrieves cached results for input text, expiring it first if the
+// configured TTL has elapsed, and otherwise marking it most-recently-used.
 func (c *Cache) Get(input string) ([]MatchResult, time.Duration, bool) {
 	key := c.hash(input)
+	shard := c.shardFor(key)
+
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
 
-	c.mutex.RLock()
-	entry, exists := c.entries[key]
-	c.mutex.RUnlock()
+	elem, exists := shard.entries[key]
+	if !exists {
+		shard.stats.Misses++
+		return nil, 0, false
+	}
 
-	if exists {
-		atomic.AddInt64(&entry.Hits, 1)
-		atomic.AddInt64(&c.stats.Hits, 1)
-		return entry.Results, entry.Duration, true
+	entry := elem.Value.(*CacheEntry)
+	if c.opts.TTL > 0 && time.Since(entry.Created) > c.opts.TTL {
+		shard.removeElement(elem)
+		shard.stats.Evictions++
+		shard.stats.Misses++
+		return nil, 0, false
 	}
 
-	atomic.AddInt64(&c.stats.Misses, 1)
-	return nil, 0, false
+	entry.Hits++
+	entry.Accessed = time.Now()
+	shard.order.MoveToFront(elem)
+	shard.stats.Hits++
+
+	return entry.Results, entry.Duration, true
 }
 
-// Put stores search results in cache
+// Put stores search results in cache, evicting least-recently-used entries
+// from the key's shard until the new entry fits within that shard's
+// entry-count and byte-size caps.
 func (c *Cache) Put(input string, results []MatchResult, duration time.Duration) {
 	key := c.hash(input)
+	shard := c.shardFor(key)
 
 	entry := &CacheEntry{
+		Key:      key,
 		Input:    input,
 		Results:  results,
 		Duration: duration,
 		Created:  time.Now(),
-		Hits:     0,
+		Accessed: time.Now(),
 	}
 
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
 
-	// Check if we need to evict entries
-	if len(c.entries) >= c.maxSize {
-		c.evictLRU()
+	if existing, ok := shard.entries[key]; ok {
+		shard.removeElement(existing)
 	}
 
-	c.entries[key] = entry
-	atomic.AddInt64(&c.stats.TotalEntries, 1)
-}
-
-// evictLRU removes the least recently used entry
-func (c *Cache) evictLRU() {
-	var oldestKey uint64
-	var oldestTime time.Time
-
-	first := true
-	for key, entry := range c.entries {
-		if first || entry.Created.Before(oldestTime) {
-			oldestKey = key
-			oldestTime = entry.Created
-			first = false
-		}
+	for c.maxEntriesPerShard > 0 && len(shard.entries) >= c.maxEntriesPerShard {
+		shard.evictOldest()
 	}
-
-	if !first {
-		delete(c.entries, oldestKey)
-		atomic.AddInt64(&c.stats.Evictions, 1)
+	for c.maxBytesPerShard > 0 && shard.order.Len() > 0 && shard.usedBytes+entry.size() > c.maxBytesPerShard {
+		shard.evictOldest()
 	}
+
+	elem := shard.order.PushFront(entry)
+	shard.entries[key] = elem
+	shard.usedBytes += entry.size()
+	shard.stats.TotalEntries++
 }
 
-// GetStats returns cache performance statistics
+// GetStats returns cache performance statistics aggregated across all
+// shards.
 func (c *Cache) GetStats() CacheStats {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
-
-	return CacheStats{
-		Hits:         atomic.LoadInt64(&c.stats.Hits),
-		Misses:       atomic.LoadInt64(&c.stats.Misses),
-		Evictions:    atomic.LoadInt64(&c.stats.Evictions),
-		TotalEntries: int64(len(c.entries)),
+	var total CacheStats
+
+	for _, shard := range c.shards {
+		shard.mutex.RLock()
+		total.Hits += shard.stats.Hits
+		total.Misses += shard.stats.Misses
+		total.Evictions += shard.stats.Evictions
+		total.TotalEntries += int64(len(shard.entries))
+		shard.mutex.RUnlock()
 	}
+
+	return total
 }
 
-// Clear removes all cached entries
+// Clear removes all cached entries from every shard.
 func (c *Cache) Clear() {
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	c.entries = make(map[uint64]*CacheEntry)
-	atomic.StoreInt64(&c.stats.Hits, 0)
-	atomic.StoreInt64(&c.stats.Misses, 0)
-	atomic.StoreInt64(&c.stats.Evictions, 0)
-	atomic.StoreInt64(&c.stats.TotalEntries, 0)
+	for _, shard := range c.shards {
+		shard.mutex.Lock()
+		shard.entries = make(map[uint64]*list.Element)
+		shard.order = list.New()
+		shard.usedBytes = 0
+		shard.stats = CacheStats{}
+		shard.mutex.Unlock()
+	}
 }
 
 // HitRatio returns the cache hit ratio as a percentage
 func (c *Cache) HitRatio() float64 {
-	hits := atomic.LoadInt64(&c.stats.Hits)
-	misses := atomic.LoadInt64(&c.stats.Misses)
-	total := hits + misses
+	stats := c.GetStats()
+	total := stats.Hits + stats.Misses
 
 	if total == 0 {
 		return 0.0
 	}
 
-	return float64(hits) / float64(total) * 100.0
+	return float64(stats.Hits) / float64(total) * 100.0
 }
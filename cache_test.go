@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sameShardKeys returns n distinct keys that hash to the same cache shard,
+// so eviction-ordering tests aren't at the mercy of which shard a literal
+// string happens to land in.
+func sameShardKeys(t *testing.T, c *Cache, n int) []string {
+	t.Helper()
+
+	groups := make(map[uint64][]string)
+	for i := 0; i < 100000; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		shard := c.hash(key) % cacheShardCount
+		groups[shard] = append(groups[shard], key)
+		if len(groups[shard]) >= n {
+			return groups[shard][:n]
+		}
+	}
+
+	t.Fatalf("could not find %d keys hashing to the same shard", n)
+	return nil
+}
+
+func TestCacheEvictsLeastRecentlyUsedNotOldestInserted(t *testing.T) {
+	cache := NewCache(2 * cacheShardCount)
+	keys := sameShardKeys(t, cache, 3)
+
+	cache.Put(keys[0], nil, 0)
+	cache.Put(keys[1], nil, 0)
+
+	// Touch keys[0] so keys[1] becomes the least-recently-used entry in
+	// their shared shard.
+	if _, _, ok := cache.Get(keys[0]); !ok {
+		t.Fatalf("expected a cache hit for %q", keys[0])
+	}
+
+	cache.Put(keys[2], nil, 0)
+
+	if _, _, ok := cache.Get(keys[1]); ok {
+		t.Fatalf("expected %q to have been evicted as the least-recently-used entry", keys[1])
+	}
+	if _, _, ok := cache.Get(keys[0]); !ok {
+		t.Fatalf("expected %q to survive eviction since it was recently accessed", keys[0])
+	}
+	if _, _, ok := cache.Get(keys[2]); !ok {
+		t.Fatalf("expected %q to be present", keys[2])
+	}
+}
+
+func TestCacheTTLExpiresEntries(t *testing.T) {
+	cache := NewCacheWithOptions(CacheOptions{MaxEntries: 10, TTL: time.Millisecond})
+
+	cache.Put("a", nil, 0)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, _, ok := cache.Get("a"); ok {
+		t.Fatal("expected entry to expire once its TTL elapsed")
+	}
+}
+
+func TestCacheMaxBytesEvicts(t *testing.T) {
+	results := []MatchResult{{Offset: 0, Length: 1, PatternID: 0, Confidence: 95, Text: "x"}}
+	entrySize := (&CacheEntry{Input: "aaaa", Results: results}).size()
+
+	cache := NewCacheWithOptions(CacheOptions{MaxBytes: (entrySize + 1) * cacheShardCount})
+	keys := sameShardKeys(t, cache, 2)
+
+	cache.Put(keys[0], results, 0)
+	cache.Put(keys[1], results, 0)
+
+	if _, _, ok := cache.Get(keys[0]); ok {
+		t.Fatalf("expected %q to be evicted once the shard's byte cap was exceeded", keys[0])
+	}
+	if _, _, ok := cache.Get(keys[1]); !ok {
+		t.Fatalf("expected %q to be present", keys[1])
+	}
+
+	if stats := cache.GetStats(); stats.Evictions == 0 {
+		t.Fatal("expected the byte-size cap to trigger an eviction")
+	}
+}
+
+func TestCacheConcurrentPutAcrossShards(t *testing.T) {
+	cache := NewCache(10000)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				key := fmt.Sprintf("worker-%d-%d", worker, i)
+				cache.Put(key, nil, 0)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if stats := cache.GetStats(); stats.TotalEntries != 1600 {
+		t.Fatalf("expected 1600 entries after concurrent puts, got %d", stats.TotalEntries)
+	}
+}
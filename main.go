@@ -23,6 +23,11 @@ type AhoCorasickMatcher struct {
 	cache       *Cache
 	automaton   *AhoCorasickAutomaton
 	initialized bool
+	matchKind   MatchKind
+	packed      *packedPrefilter
+	unicodeMode bool
+	foldedLens  []int // per-pattern folded byte length, only populated in unicodeMode
+	compact     *CompactAutomaton
 }
 
 // AhoCorasickAutomaton represents the DFA state machine
@@ -30,13 +35,23 @@ type AhoCorasickAutomaton struct {
 	states       []ACState
 	stateCount   int
 	patternCount int
+	patterns     []string // retained so a serialized automaton can be reloaded without the original patterns file
 }
 
-// ACState represents a single state in the automaton
+// ACState represents a single state in the automaton. next is a slice
+// rather than a fixed [256]int array so a loaded automaton can alias the
+// mmap'd bytes of a serialized file directly instead of decoding them into
+// a fresh array per state - see LoadAhoCorasickAutomatonMmap.
 type ACState struct {
-	next    [256]int // Next state transitions
-	failure int      // Failure link
-	outputs []int    // Pattern IDs ending at this state
+	next    []int32 // Next state transitions, always length 256
+	failure int     // Failure link
+	outputs []int   // Pattern IDs ending at this state
+}
+
+// newACState returns a state with a freshly allocated transition table,
+// used everywhere a state is built in memory rather than loaded from disk.
+func newACState() ACState {
+	return ACState{next: make([]int32, 256)}
 }
 
 // Legal hearsay patterns for demo (fallback if no file provided)
@@ -95,6 +110,10 @@ func NewAhoCorasickMatcher(patternsFile string) (*AhoCorasickMatcher, error) {
 	matcher.automaton = automaton
 	matcher.initialized = true
 
+	if packed, ok := newPackedPrefilter(matcher.patterns); ok {
+		matcher.packed = packed
+	}
+
 	buildTime := time.Since(start)
 	fmt.Printf("✅ DFA built: %d states, %v\n", automaton.stateCount, buildTime)
 	fmt.Printf("✅ DFA-based matcher ready with %d patterns\n", len(matcher.patterns))
@@ -105,15 +124,10 @@ func NewAhoCorasickMatcher(patternsFile string) (*AhoCorasickMatcher, error) {
 // buildAhoCorasickAutomaton constructs the DFA from patterns
 func buildAhoCorasickAutomaton(patterns []string) (*AhoCorasickAutomaton, error) {
 	ac := &AhoCorasickAutomaton{
-		states:       make([]ACState, 1), // Start with root state
+		states:       []ACState{newACState()}, // Start with root state
 		stateCount:   1,
 		patternCount: len(patterns),
-	}
-
-	// Initialize root state
-	ac.states[0] = ACState{
-		failure: 0,
-		outputs: nil,
+		patterns:     patterns,
 	}
 
 	// Build goto function (trie construction)
@@ -129,15 +143,12 @@ func buildAhoCorasickAutomaton(patterns []string) (*AhoCorasickAutomaton, error)
 
 			if ac.states[state].next[c] == 0 {
 				// Create new state
-				ac.states = append(ac.states, ACState{
-					failure: 0,
-					outputs: nil,
-				})
-				ac.states[state].next[c] = ac.stateCount
+				ac.states = append(ac.states, newACState())
+				ac.states[state].next[c] = int32(ac.stateCount)
 				ac.stateCount++
 			}
 
-			state = ac.states[state].next[c]
+			state = int(ac.states[state].next[c])
 		}
 
 		// Mark this state as accepting this pattern
@@ -149,7 +160,7 @@ func buildAhoCorasickAutomaton(patterns []string) (*AhoCorasickAutomaton, error)
 
 	// Initialize failure links for depth-1 states
 	for c := 0; c < 256; c++ {
-		state := ac.states[0].next[c]
+		state := int(ac.states[0].next[c])
 		if state != 0 {
 			ac.states[state].failure = 0
 			queue = append(queue, state)
@@ -162,7 +173,7 @@ func buildAhoCorasickAutomaton(patterns []string) (*AhoCorasickAutomaton, error)
 		queue = queue[1:]
 
 		for c := 0; c < 256; c++ {
-			u := ac.states[r].next[c]
+			u := int(ac.states[r].next[c])
 			if u == 0 {
 				continue
 			}
@@ -174,7 +185,7 @@ func buildAhoCorasickAutomaton(patterns []string) (*AhoCorasickAutomaton, error)
 				state = ac.states[state].failure
 			}
 
-			ac.states[u].failure = ac.states[state].next[c]
+			ac.states[u].failure = int(ac.states[state].next[c])
 
 			// Copy outputs from failure state
 			failureState := ac.states[u].failure
@@ -230,6 +241,32 @@ func (m *AhoCorasickMatcher) Search(text string) ([]MatchResult, time.Duration,
 
 	start := time.Now()
 
+	raw := m.rawMatches(text)
+	results := resolveMatchKind(raw, m.matchKind)
+
+	elapsed := time.Since(start)
+
+	// Cache the results
+	m.cache.Put(text, results, elapsed)
+
+	return results, elapsed, nil
+}
+
+// rawMatches scans text with the DFA and reports every overlapping output as
+// soon as its state is entered (the Standard match-kind semantics).
+func (m *AhoCorasickMatcher) rawMatches(text string) []MatchResult {
+	if m.compact != nil {
+		return m.compactMatches(text)
+	}
+
+	if m.unicodeMode {
+		return m.rawMatchesUnicode(text)
+	}
+
+	if m.packed != nil {
+		return m.packed.Scan(text)
+	}
+
 	// Convert to lowercase for case-insensitive matching
 	lowerText := strings.ToLower(text)
 
@@ -248,7 +285,7 @@ func (m *AhoCorasickMatcher) Search(text string) ([]MatchResult, time.Duration,
 			state = m.automaton.states[state].failure
 		}
 
-		state = m.automaton.states[state].next[c]
+		state = int(m.automaton.states[state].next[c])
 
 		// Check for matches at this state
 		for _, patternID := range m.automaton.states[state].outputs {
@@ -270,12 +307,7 @@ func (m *AhoCorasickMatcher) Search(text string) ([]MatchResult, time.Duration,
 		}
 	}
 
-	elapsed := time.Since(start)
-
-	// Cache the results
-	m.cache.Put(text, results, elapsed)
-
-	return results, elapsed, nil
+	return results
 }
 
 // GetPatternName returns the pattern name for an ID
@@ -297,12 +329,34 @@ func (m *AhoCorasickMatcher) GetAhoCorasickStats() map[string]interface{} {
 		return map[string]interface{}{}
 	}
 
-	return map[string]interface{}{
+	searchPath := "dfa"
+	if m.packed != nil {
+		searchPath = "packed-teddy-scalar"
+	}
+	if m.compact != nil {
+		searchPath = "byte-class-compact"
+	}
+
+	stats := map[string]interface{}{
 		"state_count":    m.automaton.stateCount,
 		"pattern_count":  m.automaton.patternCount,
 		"implementation": "Pure Go DFA",
 		"algorithm":      "Aho-Corasick",
+		"search_path":    searchPath,
+	}
+
+	if m.compact != nil {
+		wide := m.compact.WideTableMemoryBytes()
+		compact := m.compact.MemoryBytes()
+		stats["byte_classes"] = m.compact.numClasses
+		stats["wide_table_memory_bytes"] = wide
+		stats["compact_table_memory_bytes"] = compact
+		if compact > 0 {
+			stats["memory_savings_x"] = float64(wide) / float64(compact)
+		}
 	}
+
+	return stats
 }
 
 // formatResults formats search results for display
@@ -429,6 +483,8 @@ func main() {
 
 	// Parse command line arguments
 	var patternsFile string
+	var compileOut string
+	var loadFile string
 	var mode string = "interactive"
 
 	for i, arg := range os.Args[1:] {
@@ -437,6 +493,14 @@ func main() {
 			if i+1 < len(os.Args)-1 {
 				patternsFile = os.Args[i+2]
 			}
+		case "--compile":
+			if i+1 < len(os.Args)-1 {
+				compileOut = os.Args[i+2]
+			}
+		case "--load":
+			if i+1 < len(os.Args)-1 {
+				loadFile = os.Args[i+2]
+			}
 		case "--benchmark", "-b":
 			mode = "benchmark"
 		case "--test", "-t":
@@ -448,6 +512,8 @@ func main() {
 			fmt.Println("  legal-nlp [options]")
 			fmt.Println("\nOptions:")
 			fmt.Println("  --patterns, -p FILE    Load patterns from file")
+			fmt.Println("  --compile FILE         Build the DFA and serialize it to FILE, then exit")
+			fmt.Println("  --load FILE            Load a previously compiled DFA instead of building one")
 			fmt.Println("  --benchmark, -b        Run performance benchmark")
 			fmt.Println("  --test, -t             Run test cases")
 			fmt.Println("  --simd                 Use SIMD-accelerated C core")
@@ -465,10 +531,40 @@ func main() {
 		}
 	}
 
-	// Initialize matcher
-	matcher, err := NewAhoCorasickMatcher(patternsFile)
-	if err != nil {
-		fmt.Printf("❌ Failed to initialize matcher: %v\n", err)
+	// Initialize matcher, either from a freshly-built DFA or a compiled one
+	var matcher *AhoCorasickMatcher
+	var err error
+
+	if loadFile != "" {
+		fmt.Printf("📦 Loading compiled DFA from %s...\n", loadFile)
+		automaton, loadErr := LoadAhoCorasickAutomatonMmap(loadFile)
+		if loadErr != nil {
+			fmt.Printf("❌ Failed to load compiled DFA: %v\n", loadErr)
+			return
+		}
+		matcher = NewAhoCorasickMatcherFromAutomaton(automaton)
+		fmt.Printf("✅ DFA loaded: %d states\n", automaton.stateCount)
+	} else {
+		matcher, err = NewAhoCorasickMatcher(patternsFile)
+		if err != nil {
+			fmt.Printf("❌ Failed to initialize matcher: %v\n", err)
+			return
+		}
+	}
+
+	if compileOut != "" {
+		f, createErr := os.Create(compileOut)
+		if createErr != nil {
+			fmt.Printf("❌ Failed to create %s: %v\n", compileOut, createErr)
+			return
+		}
+		written, writeErr := matcher.automaton.WriteTo(f)
+		f.Close()
+		if writeErr != nil {
+			fmt.Printf("❌ Failed to compile DFA: %v\n", writeErr)
+			return
+		}
+		fmt.Printf("✅ Compiled DFA written to %s (%d bytes)\n", compileOut, written)
 		return
 	}
 
@@ -0,0 +1,178 @@
+package main
+
+import "strings"
+
+// packedThresholdPatterns caps the pattern-set size the Teddy-style packed
+// prefilter is considered for; above this the DFA's per-state outputs are
+// already cheap enough that skipping ahead isn't worth the extra bucket
+// bookkeeping.
+const packedThresholdPatterns = 64
+
+// packedMinPatternLen is the shortest pattern the prefilter can safely build
+// a bucket key from. It must be at least packedKeyLen: a shorter pattern has
+// no byte at some key position, and candidateBuckets has no way to tell "no
+// byte here, any input matches" from "the byte here must be 0x00" - the
+// latter almost never holds in real text, so shorter patterns would have
+// their matches silently dropped instead of just being weaker prefilter
+// candidates.
+const packedMinPatternLen = packedKeyLen
+
+// packedKeyLen is the number of leading bytes used as each pattern's bucket
+// key (N in the Teddy literature). 3 gives fewer false-positive candidates
+// than 2 at the cost of needing patterns at least that long.
+const packedKeyLen = 3
+
+// packedBuckets is the number of buckets tracked per nibble lookup table,
+// matching the 8-lane layout Teddy's SSE/AVX2 kernels pack into a byte mask.
+const packedBuckets = 8
+
+// packedPrefilter narrows candidate start positions in text using nibble
+// lookup tables loosely modeled on the Teddy algorithm from the Rust
+// aho-corasick crate: each pattern is assigned to one of packedBuckets
+// buckets by its key bytes, and two 16-entry tables per key byte record
+// which buckets could match on that byte's low/high nibble. ANDing the
+// per-position masks together leaves only buckets that are still plausible
+// at a given offset.
+//
+// This is a scalar-only stand-in for Teddy, not Teddy itself: real Teddy
+// kernels load 16/32 bytes per step and do the nibble lookups in a single
+// SIMD shuffle (AVX2 via golang.org/x/sys/cpu feature detection plus Go
+// assembly, NEON similarly). None of that exists here - this is a plain
+// byte-at-a-time loop over the same bucket-mask idea. When a matcher has
+// one, rawMatches returns Scan's results in place of the DFA walk; the DFA
+// does not verify them.
+type packedPrefilter struct {
+	keyLen   int
+	buckets  [][]int // pattern IDs assigned to each bucket
+	lowMask  [packedKeyLen][16]uint8
+	highMask [packedKeyLen][16]uint8
+	patterns []string
+}
+
+// newPackedPrefilter builds a packed prefilter for patterns, or reports ok
+// == false if the pattern set doesn't meet the size/length thresholds the
+// Teddy approach pays off for.
+func newPackedPrefilter(patterns []string) (p *packedPrefilter, ok bool) {
+	if len(patterns) == 0 || len(patterns) > packedThresholdPatterns {
+		return nil, false
+	}
+
+	for _, pattern := range patterns {
+		if len(pattern) < packedMinPatternLen {
+			return nil, false
+		}
+	}
+
+	pf := &packedPrefilter{
+		keyLen:   packedKeyLen,
+		buckets:  make([][]int, packedBuckets),
+		patterns: patterns,
+	}
+
+	for id, pattern := range patterns {
+		key := strings.ToLower(pattern)
+		bucket := bucketForKey(key)
+		pf.buckets[bucket] = append(pf.buckets[bucket], id)
+
+		for pos := 0; pos < pf.keyLen; pos++ {
+			var b byte
+			if pos < len(key) {
+				b = key[pos]
+			}
+			lo := b & 0x0f
+			hi := (b >> 4) & 0x0f
+			pf.lowMask[pos][lo] |= 1 << uint(bucket)
+			pf.highMask[pos][hi] |= 1 << uint(bucket)
+		}
+	}
+
+	return pf, true
+}
+
+// bucketForKey derives a pattern's bucket from its first packedKeyLen bytes
+// (falling back to whatever's available for shorter keys), so patterns with
+// different prefixes land in different buckets and the nibble masks built
+// from those prefixes actually narrow candidates. Assigning buckets by
+// pattern ID instead, as an earlier version of this file did, scatters
+// same-prefix patterns across unrelated buckets and groups unrelated
+// prefixes together, which defeats the nibble masks' whole purpose.
+func bucketForKey(key string) int {
+	var h uint32 = 2166136261 // FNV-1a offset basis
+	n := packedKeyLen
+	if len(key) < n {
+		n = len(key)
+	}
+	for i := 0; i < n; i++ {
+		h ^= uint32(key[i])
+		h *= 16777619 // FNV-1a prime
+	}
+	return int(h % packedBuckets)
+}
+
+// candidateBuckets returns the bitmask of buckets whose patterns could still
+// start at lowerText[i:], after ANDing together the per-position nibble
+// masks. A zero result means no pattern in any bucket can start here.
+func (p *packedPrefilter) candidateBuckets(lowerText string, i int) uint8 {
+	mask := uint8(0xff)
+
+	for pos := 0; pos < p.keyLen; pos++ {
+		if i+pos >= len(lowerText) {
+			return 0
+		}
+
+		b := lowerText[i+pos]
+		lo := b & 0x0f
+		hi := (b >> 4) & 0x0f
+		mask &= p.lowMask[pos][lo] & p.highMask[pos][hi]
+
+		if mask == 0 {
+			return 0
+		}
+	}
+
+	return mask
+}
+
+// Scan walks text looking for offsets the bucket tables flag as candidates,
+// then verifies each one with a direct byte compare against the full
+// pattern (patterns here are flat literals, so a byte compare from the
+// candidate offset is equivalent to walking the DFA's remaining suffix).
+// Results are shaped like AhoCorasickMatcher.rawMatches's and ordered by
+// start offset, so rawMatches can return them directly in place of its DFA
+// walk.
+func (p *packedPrefilter) Scan(text string) []MatchResult {
+	lowerText := strings.ToLower(text)
+
+	var results []MatchResult
+
+	for i := 0; i < len(lowerText); i++ {
+		mask := p.candidateBuckets(lowerText, i)
+		if mask == 0 {
+			continue
+		}
+
+		for bucket := 0; bucket < packedBuckets; bucket++ {
+			if mask&(1<<uint(bucket)) == 0 {
+				continue
+			}
+
+			for _, patternID := range p.buckets[bucket] {
+				pattern := strings.ToLower(p.patterns[patternID])
+				end := i + len(pattern)
+				if end > len(lowerText) || lowerText[i:end] != pattern {
+					continue
+				}
+
+				results = append(results, MatchResult{
+					Offset:     uint64(i),
+					Length:     uint64(len(pattern)),
+					PatternID:  uint32(patternID),
+					Confidence: 95,
+					Text:       text[i:end],
+				})
+			}
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,80 @@
+package main
+
+import "testing"
+
+func TestPackedPrefilterMatchesDFA(t *testing.T) {
+	patterns := []string{"he said", "she told", "allegedly"}
+	text := "she told me he said it allegedly happened"
+
+	dfa, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+
+	dfaMatcher := &AhoCorasickMatcher{patterns: patterns, automaton: dfa, initialized: true}
+	dfaResults := dfaMatcher.rawMatches(text)
+
+	packed, ok := newPackedPrefilter(patterns)
+	if !ok {
+		t.Fatalf("expected packed prefilter to be eligible for %d short patterns", len(patterns))
+	}
+	packedResults := packed.Scan(text)
+
+	if len(packedResults) != len(dfaResults) {
+		t.Fatalf("packed found %d matches, DFA found %d: packed=%+v dfa=%+v",
+			len(packedResults), len(dfaResults), packedResults, dfaResults)
+	}
+
+	for i := range dfaResults {
+		if packedResults[i].Offset != dfaResults[i].Offset || packedResults[i].Text != dfaResults[i].Text {
+			t.Fatalf("match %d differs: packed=%+v dfa=%+v", i, packedResults[i], dfaResults[i])
+		}
+	}
+}
+
+func TestBucketForKeyDerivesFromKeyBytesNotID(t *testing.T) {
+	// Two patterns sharing the same first packedKeyLen bytes must land in
+	// the same bucket no matter what pattern IDs they're assigned - the
+	// whole point of keying the nibble masks off those bytes. An
+	// id%packedBuckets assignment would scatter them across unrelated
+	// buckets instead.
+	if got, want := bucketForKey("allegedly"), bucketForKey("allegedly claims"); got != want {
+		t.Fatalf("patterns sharing a prefix landed in different buckets: %d vs %d", got, want)
+	}
+
+	// A key shorter than packedKeyLen still gets a bucket rather than
+	// panicking on an out-of-range slice.
+	bucketForKey("a")
+}
+
+func TestPackedPrefilterThresholds(t *testing.T) {
+	if _, ok := newPackedPrefilter([]string{"a"}); ok {
+		t.Fatal("expected single-byte pattern to be rejected (below packedMinPatternLen)")
+	}
+
+	many := make([]string, packedThresholdPatterns+1)
+	for i := range many {
+		many[i] = "said"
+	}
+	if _, ok := newPackedPrefilter(many); ok {
+		t.Fatal("expected pattern set above packedThresholdPatterns to be rejected")
+	}
+}
+
+// TestPackedPrefilterRejectsPatternsShorterThanKeyLen guards against a case
+// where a pattern shorter than packedKeyLen was built with its missing key
+// bytes padded as 0x00, which candidateBuckets then required the real input
+// byte to also be 0x00 to match - something real text essentially never
+// does, so the prefilter silently dropped every match for that pattern.
+// packedMinPatternLen must stay >= packedKeyLen so such patterns are
+// rejected outright (and fall back to the plain DFA) instead of being
+// silently broken.
+func TestPackedPrefilterRejectsPatternsShorterThanKeyLen(t *testing.T) {
+	if packedMinPatternLen < packedKeyLen {
+		t.Fatalf("packedMinPatternLen (%d) must be at least packedKeyLen (%d)", packedMinPatternLen, packedKeyLen)
+	}
+
+	if _, ok := newPackedPrefilter([]string{"ok", "no"}); ok {
+		t.Fatal("expected patterns shorter than packedKeyLen to be rejected")
+	}
+}
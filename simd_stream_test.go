@@ -0,0 +1,115 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSIMDSearchStreamSplitAcrossChunkBoundary(t *testing.T) {
+	patterns := []string{"he said", "allegedly"}
+	automaton, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+	matcher := &SIMDMatcher{patterns: patterns, automaton: automaton, cache: NewCache(10), initialized: true}
+
+	text := "before he said allegedly after"
+
+	oldChunkSize := simdStreamChunkSize
+	defer func() { simdStreamChunkSize = oldChunkSize }()
+
+	// Shrink the chunk size so "he said" and "allegedly" are each forced to
+	// straddle a read boundary.
+	simdStreamChunkSize = 10
+
+	var got []MatchResult
+	stats, err := matcher.SearchStream(strings.NewReader(text), func(m MatchResult) bool {
+		got = append(got, m)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("SearchStream: %v", err)
+	}
+	if stats.MatchesFound != int64(len(got)) {
+		t.Fatalf("stats.MatchesFound = %d, want %d", stats.MatchesFound, len(got))
+	}
+
+	want, _, err := matcher.Search(text)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d matches, want %d: got=%+v want=%+v", len(got), len(want), got, want)
+	}
+	for i := range want {
+		if got[i].Offset != want[i].Offset || got[i].Text != want[i].Text {
+			t.Fatalf("match %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSIMDScannerYieldsEachMatch(t *testing.T) {
+	patterns := []string{"he said", "allegedly"}
+	automaton, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+	matcher := &SIMDMatcher{patterns: patterns, automaton: automaton, cache: NewCache(10), initialized: true}
+
+	text := "before he said allegedly after"
+	scanner := matcher.NewScanner(strings.NewReader(text))
+
+	var got []MatchResult
+	for scanner.Scan() {
+		got = append(got, scanner.Match())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Scanner.Err: %v", err)
+	}
+
+	want, _, err := matcher.Search(text)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d matches, want %d: got=%+v want=%+v", len(got), len(want), got, want)
+	}
+}
+
+func TestSIMDSearchBatchMatchesSequentialSearch(t *testing.T) {
+	patterns := []string{"he said", "allegedly"}
+	automaton, err := buildAhoCorasickAutomaton(patterns)
+	if err != nil {
+		t.Fatalf("buildAhoCorasickAutomaton: %v", err)
+	}
+	matcher := &SIMDMatcher{patterns: patterns, automaton: automaton, cache: NewCache(100), initialized: true}
+
+	texts := []string{
+		"before he said allegedly after",
+		"clean text with no hits",
+		"allegedly he said it twice, he said",
+	}
+
+	results, durations, err := matcher.SearchBatch(texts, 4)
+	if err != nil {
+		t.Fatalf("SearchBatch: %v", err)
+	}
+	if len(results) != len(texts) || len(durations) != len(texts) {
+		t.Fatalf("SearchBatch returned %d results, %d durations for %d texts", len(results), len(durations), len(texts))
+	}
+
+	for i, text := range texts {
+		want, _, err := matcher.Search(text)
+		if err != nil {
+			t.Fatalf("Search(%q): %v", text, err)
+		}
+		if len(results[i]) != len(want) {
+			t.Fatalf("text %d: got %d matches, want %d: got=%+v want=%+v", i, len(results[i]), len(want), results[i], want)
+		}
+		for j := range want {
+			if results[i][j].Offset != want[j].Offset || results[i][j].Text != want[j].Text {
+				t.Fatalf("text %d match %d: got %+v, want %+v", i, j, results[i][j], want[j])
+			}
+		}
+	}
+}
@@ -4,14 +4,682 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
-// mainSIMD stub for Pure Go builds (SIMD requires CGO)
+// SIMDMatcher mirrors the CGO-accelerated matcher's API (constructor,
+// Search, GetPatternName, Cleanup, stats) using the pure-Go Aho-Corasick DFA
+// from main.go instead of the SIMD C core, so --simd still works on
+// platforms where CGO/AVX isn't available instead of exiting with code 1.
+// MatchResult layout and Search behavior are byte-identical to the CGO
+// path, so the cache layer is shared unmodified.
+type SIMDMatcher struct {
+	patterns    []string
+	cache       *Cache
+	automaton   *AhoCorasickAutomaton
+	initialized bool
+}
+
+// NewSIMDMatcher creates a SIMDMatcher backed by the pure-Go DFA.
+func NewSIMDMatcher(patternsFile string) (*SIMDMatcher, error) {
+	matcher := &SIMDMatcher{
+		cache: NewCache(10000),
+	}
+
+	if patternsFile != "" {
+		patterns, err := loadPatternsFromFile(patternsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load patterns from %s: %v", patternsFile, err)
+		}
+		matcher.patterns = patterns
+	} else {
+		matcher.patterns = LegalPatterns
+	}
+
+	automaton, err := buildAhoCorasickAutomaton(matcher.patterns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Aho-Corasick automaton: %v", err)
+	}
+
+	matcher.automaton = automaton
+	matcher.initialized = true
+	fmt.Printf("✅ SIMD matcher ready with %d patterns (pure-Go fallback)\n", len(matcher.patterns))
+
+	return matcher, nil
+}
+
+// Search walks the pure-Go DFA, reporting every output at every state it
+// enters in O(n+z) for input length n and match count z.
+func (m *SIMDMatcher) Search(text string) ([]MatchResult, time.Duration, error) {
+	if !m.initialized {
+		return nil, 0, fmt.Errorf("matcher not initialized")
+	}
+
+	if results, duration, found := m.cache.Get(text); found {
+		return results, duration, nil
+	}
+
+	start := time.Now()
+
+	lowerText := strings.ToLower(text)
+	var results []MatchResult
+	state := 0
+
+	for i, char := range lowerText {
+		c := int(char)
+		if c >= 256 {
+			continue // Skip non-ASCII characters
+		}
+
+		for state != 0 && m.automaton.states[state].next[c] == 0 {
+			state = m.automaton.states[state].failure
+		}
+		state = int(m.automaton.states[state].next[c])
+
+		for _, patternID := range m.automaton.states[state].outputs {
+			if patternID < len(m.patterns) {
+				pattern := m.patterns[patternID]
+				patternLen := len(pattern)
+				offset := i - patternLen + 1
+
+				if offset >= 0 && offset+patternLen <= len(text) {
+					results = append(results, MatchResult{
+						Offset:     uint64(offset),
+						Length:     uint64(patternLen),
+						PatternID:  uint32(patternID),
+						Confidence: 95,
+						Text:       pattern,
+					})
+				}
+			}
+		}
+	}
+
+	elapsed := time.Since(start)
+	m.cache.Put(text, results, elapsed)
+
+	return results, elapsed, nil
+}
+
+// BatchStats reports per-worker throughput for a SearchBatch call.
+type BatchStats struct {
+	Workers      int
+	TotalInputs  int
+	TotalMatches int64
+	Duration     time.Duration
+	PerWorker    []int // inputs processed by each worker, indexed by worker ID
+}
+
+// SearchBatch dispatches texts across a bounded pool of workers, each
+// calling into the pure-Go DFA independently so one slow or large input
+// doesn't block the others. Results and durations are returned in the same
+// order as texts. workers <= 0 defaults to 1.
+func (m *SIMDMatcher) SearchBatch(texts []string, workers int) ([][]MatchResult, []time.Duration, error) {
+	if !m.initialized {
+		return nil, nil, fmt.Errorf("matcher not initialized")
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+
+	results := make([][]MatchResult, len(texts))
+	durations := make([]time.Duration, len(texts))
+	errs := make([]error, len(texts))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r, d, err := m.Search(texts[i])
+				results[i] = r
+				durations[i] = d
+				errs[i] = err
+			}
+		}()
+	}
+
+	for i := range texts {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return results, durations, nil
+}
+
+// maxPatternLen returns the length of the longest loaded pattern, used to
+// size the overlap window between successive chunks.
+func (m *SIMDMatcher) maxPatternLen() int {
+	max := 0
+	for _, p := range m.patterns {
+		if len(p) > max {
+			max = len(p)
+		}
+	}
+	return max
+}
+
+// Stats summarizes a SearchStream run.
+type Stats struct {
+	BytesScanned  int64
+	ChunksScanned int
+	MatchesFound  int64
+	Duration      time.Duration
+}
+
+// simdStreamChunkSize is the amount of new data read per SearchStream
+// iteration before it's walked through the DFA. It's a var (not a const) so
+// tests can shrink it to exercise chunk-boundary behavior without
+// allocating gigabytes of test input.
+var simdStreamChunkSize = 64 * 1024
+
+// SearchStream scans r in bounded-size chunks so multi-gigabyte corpora can
+// be fed in without materializing them as one Go string, unlocking pipeline
+// use cases like `cat file | legal-nlp-simd`. The DFA state carries over
+// between chunks so failure links still work across a boundary; every newly
+// read byte is processed immediately, and only a trailing window of
+// maxPatternLen-1 bytes is kept in the buffer afterwards, just enough to
+// compute the Offset of a match that completes in a later chunk. Results stream to cb as they're produced - cb returns false to
+// stop early - and the per-input cache is bypassed entirely in streaming mode
+// to avoid unbounded memory growth.
+func (m *SIMDMatcher) SearchStream(r io.Reader, cb func(MatchResult) bool) (Stats, error) {
+	if !m.initialized {
+		return Stats{}, fmt.Errorf("matcher not initialized")
+	}
+
+	start := time.Now()
+	var stats Stats
+
+	overlap := m.maxPatternLen() - 1
+	if overlap < 0 {
+		overlap = 0
+	}
+
+	buf := make([]byte, 0, simdStreamChunkSize+overlap)
+	readBuf := make([]byte, simdStreamChunkSize)
+	state := 0
+	var base uint64 // absolute stream offset of buf[0]
+
+	for {
+		n, err := r.Read(readBuf)
+		if n > 0 {
+			stats.ChunksScanned++
+			stats.BytesScanned += int64(n)
+
+			bufStart := len(buf)
+			buf = append(buf, readBuf[:n]...)
+			lower := strings.ToLower(string(buf[bufStart:]))
+
+			for j := 0; j < len(lower); j++ {
+				i := bufStart + j
+				c := int(lower[j])
+				if c >= 256 {
+					continue // never true for a single byte: every byte, including those of multi-byte runes, is fed to the DFA
+				}
+
+				for state != 0 && m.automaton.states[state].next[c] == 0 {
+					state = m.automaton.states[state].failure
+				}
+				state = int(m.automaton.states[state].next[c])
+
+				for _, patternID := range m.automaton.states[state].outputs {
+					if patternID >= len(m.patterns) {
+						continue
+					}
+
+					patternLen := len(m.patterns[patternID])
+					localOffset := i - patternLen + 1
+					if localOffset < 0 {
+						continue
+					}
+
+					stats.MatchesFound++
+					if !cb(MatchResult{
+						Offset:     base + uint64(localOffset),
+						Length:     uint64(patternLen),
+						PatternID:  uint32(patternID),
+						Confidence: 95,
+						Text:       m.patterns[patternID],
+					}) {
+						stats.Duration = time.Since(start)
+						return stats, nil
+					}
+				}
+			}
+
+			if len(buf) > overlap {
+				drop := len(buf) - overlap
+				base += uint64(drop)
+				buf = append(buf[:0], buf[drop:]...)
+			}
+		}
+
+		if err == io.EOF {
+			stats.Duration = time.Since(start)
+			return stats, nil
+		} else if err != nil {
+			stats.Duration = time.Since(start)
+			return stats, err
+		}
+	}
+}
+
+// Scanner provides a bufio.Scanner-style pull API over SearchStream for
+// callers that would rather loop than pass a callback.
+type Scanner struct {
+	matches chan MatchResult
+	done    chan struct{}
+	err     error
+	current MatchResult
+}
+
+// NewScanner starts scanning r in the background and returns a Scanner that
+// yields one match per Scan call.
+func (m *SIMDMatcher) NewScanner(r io.Reader) *Scanner {
+	s := &Scanner{
+		matches: make(chan MatchResult),
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(s.matches)
+		_, err := m.SearchStream(r, func(match MatchResult) bool {
+			select {
+			case s.matches <- match:
+				return true
+			case <-s.done:
+				return false
+			}
+		})
+		s.err = err
+	}()
+
+	return s
+}
+
+// Scan advances to the next match, returning false once the stream is
+// exhausted or an error occurred (check Err).
+func (s *Scanner) Scan() bool {
+	match, ok := <-s.matches
+	if !ok {
+		return false
+	}
+	s.current = match
+	return true
+}
+
+// Match returns the match produced by the most recent Scan call.
+func (s *Scanner) Match() MatchResult {
+	return s.current
+}
+
+// Err returns the first error encountered while scanning, if any.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// Close stops the background scan goroutine if the caller abandons the
+// Scanner before it's exhausted.
+func (s *Scanner) Close() {
+	close(s.done)
+}
+
+// GetSIMDStats returns stats shaped like the CGO path's, reporting the
+// pure-Go fallback variant and zero SIMD utilization.
+func (m *SIMDMatcher) GetSIMDStats() map[string]interface{} {
+	if !m.initialized {
+		return map[string]interface{}{}
+	}
+
+	cacheStats := m.cache.GetStats()
+
+	return map[string]interface{}{
+		"total_searches":     uint64(cacheStats.Hits + cacheStats.Misses),
+		"total_matches":      uint64(0),
+		"simd_operations":    uint64(0),
+		"cache_hits":         uint64(cacheStats.Hits),
+		"avg_search_time_ns": uint64(0),
+		"simd_utilization":   float64(0),
+		"simd_variant":       "pure-go",
+		"cpu_info":           "CGO disabled: pure-Go Aho-Corasick fallback",
+	}
+}
+
+// GetPatternName returns the pattern name for an ID.
+func (m *SIMDMatcher) GetPatternName(patternID uint32) string {
+	if int(patternID) < len(m.patterns) {
+		return m.patterns[patternID]
+	}
+	return fmt.Sprintf("unknown-%d", patternID)
+}
+
+// GetCacheStats returns cache performance statistics.
+func (m *SIMDMatcher) GetCacheStats() CacheStats {
+	return m.cache.GetStats()
+}
+
+// Cleanup releases matcher resources. The pure-Go path has nothing to free;
+// it exists so callers don't need a build-tag switch.
+func (m *SIMDMatcher) Cleanup() {
+	m.initialized = false
+}
+
+// displaySIMDStats shows performance and cache statistics.
+func displaySIMDStats(matcher *SIMDMatcher, totalSearches, totalMatches int64, totalTime time.Duration) {
+	cacheStats := matcher.GetCacheStats()
+	simdStats := matcher.GetSIMDStats()
+
+	fmt.Printf("\n📊 Performance Statistics:\n")
+	fmt.Printf("   Total Searches: %d\n", totalSearches)
+	fmt.Printf("   Total Matches: %d\n", totalMatches)
+	fmt.Printf("   Total Time: %v\n", totalTime)
+	if totalSearches > 0 {
+		fmt.Printf("   Avg Time/Search: %v\n", totalTime/time.Duration(totalSearches))
+		fmt.Printf("   Searches/Second: %.0f\n", float64(totalSearches)/totalTime.Seconds())
+	}
+
+	fmt.Printf("\n🗄️  Cache Statistics:\n")
+	fmt.Printf("   Cache Hits: %d\n", cacheStats.Hits)
+	fmt.Printf("   Cache Misses: %d\n", cacheStats.Misses)
+	fmt.Printf("   Hit Ratio: %.1f%%\n", matcher.cache.HitRatio())
+	fmt.Printf("   Cached Entries: %d\n", cacheStats.TotalEntries)
+
+	if len(simdStats) > 0 {
+		fmt.Printf("\n⚡ SIMD Core Statistics:\n")
+		fmt.Printf("   SIMD Variant: %v\n", simdStats["simd_variant"])
+		fmt.Printf("   CPU Info: %v\n", simdStats["cpu_info"])
+		fmt.Printf("   Core Searches: %v\n", simdStats["total_searches"])
+		fmt.Printf("   Core Matches: %v\n", simdStats["total_matches"])
+		fmt.Printf("   SIMD Operations: %v\n", simdStats["simd_operations"])
+		fmt.Printf("   SIMD Utilization: %.1f%%\n", simdStats["simd_utilization"])
+	}
+}
+
+// runSIMDBenchmark performs performance testing.
+func runSIMDBenchmark(matcher *SIMDMatcher) {
+	fmt.Println("🚀 Running SIMD Aho-Corasick benchmark...")
+
+	testTexts := []string{
+		"he said the defendant was guilty",
+		"according to the witness testimony, the case was clear",
+		"she told me that it happened yesterday during the meeting",
+		"the contract was signed without any issues whatsoever",
+		"reportedly there were serious problems with the case",
+		"i heard from multiple sources about this incident",
+		"this is clean legal text with no hearsay indicators",
+		"witnesses claim that the events unfolded differently",
+		"testimony indicates a pattern of misconduct over time",
+		"didn't you say something different during your deposition",
+		"plaintiff claims damages in excess of one million dollars",
+		"defendant stated under oath that the allegations were false",
+		"court records show a pattern of similar complaints",
+		"evidence suggests that the incident occurred as described",
+		"witness testified that they saw the defendant at the scene",
+	}
+
+	iterations := 10000
+	start := time.Now()
+	totalMatches := 0
+
+	for i := 0; i < iterations; i++ {
+		for _, text := range testTexts {
+			results, _, err := matcher.Search(text)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+			totalMatches += len(results)
+		}
+	}
+
+	elapsed := time.Since(start)
+	totalSearches := iterations * len(testTexts)
+
+	fmt.Printf("\n🏁 SIMD Benchmark Results:\n")
+	fmt.Printf("   Iterations: %d\n", iterations)
+	fmt.Printf("   Test Texts: %d\n", len(testTexts))
+	fmt.Printf("   Total Searches: %d\n", totalSearches)
+	fmt.Printf("   Total Matches: %d\n", totalMatches)
+	fmt.Printf("   Total Time: %v\n", elapsed)
+	fmt.Printf("   Avg Time/Search: %v\n", elapsed/time.Duration(totalSearches))
+	fmt.Printf("   Searches/Second: %.0f\n", float64(totalSearches)/elapsed.Seconds())
+	fmt.Printf("   Cache Hit Ratio: %.1f%%\n", matcher.cache.HitRatio())
+}
+
+// runSIMDBatchBenchmark exercises SearchBatch with the given worker pool
+// size so operators can size it to their CPU, reporting per-worker
+// throughput alongside the usual aggregate numbers.
+func runSIMDBatchBenchmark(matcher *SIMDMatcher, workers int) {
+	fmt.Printf("🚀 Running SIMD Aho-Corasick batch benchmark (%d workers)...\n", workers)
+
+	testTexts := []string{
+		"he said the defendant was guilty",
+		"according to the witness testimony, the case was clear",
+		"she told me that it happened yesterday during the meeting",
+		"the contract was signed without any issues whatsoever",
+		"reportedly there were serious problems with the case",
+		"i heard from multiple sources about this incident",
+		"this is clean legal text with no hearsay indicators",
+		"witnesses claim that the events unfolded differently",
+		"testimony indicates a pattern of misconduct over time",
+		"didn't you say something different during your deposition",
+		"plaintiff claims damages in excess of one million dollars",
+		"defendant stated under oath that the allegations were false",
+		"court records show a pattern of similar complaints",
+		"evidence suggests that the incident occurred as described",
+		"witness testified that they saw the defendant at the scene",
+	}
+
+	iterations := 10000
+	inputs := make([]string, 0, iterations*len(testTexts))
+	for i := 0; i < iterations; i++ {
+		inputs = append(inputs, testTexts...)
+	}
+
+	start := time.Now()
+	results, _, err := matcher.SearchBatch(inputs, workers)
+	elapsed := time.Since(start)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	totalMatches := 0
+	for _, r := range results {
+		totalMatches += len(r)
+	}
+
+	perWorker := make([]int, workers)
+	for i := range inputs {
+		perWorker[i%workers]++
+	}
+
+	fmt.Printf("\n🏁 SIMD Batch Benchmark Results:\n")
+	fmt.Printf("   Workers: %d\n", workers)
+	fmt.Printf("   Total Searches: %d\n", len(inputs))
+	fmt.Printf("   Total Matches: %d\n", totalMatches)
+	fmt.Printf("   Total Time: %v\n", elapsed)
+	fmt.Printf("   Searches/Second: %.0f\n", float64(len(inputs))/elapsed.Seconds())
+	fmt.Printf("   Cache Hit Ratio: %.1f%%\n", matcher.cache.HitRatio())
+
+	fmt.Printf("\n👷 Per-Worker Throughput (approximate, round-robin dispatch order):\n")
+	perWorkerRate := float64(len(inputs)) / elapsed.Seconds() / float64(workers)
+	for i, count := range perWorker {
+		fmt.Printf("   Worker %d: %d searches (~%.0f/s)\n", i, count, perWorkerRate)
+	}
+}
+
+// mainSIMD runs the --simd CLI path using the pure-Go fallback matcher.
 func mainSIMD() {
-	fmt.Println("❌ SIMD mode not available in Pure Go build")
-	fmt.Println("💡 SIMD requires CGO. Build with: make legal-nlp-simd")
-	fmt.Println("🔵 Using Pure Go mode instead...")
-	os.Exit(1)
+	fmt.Println("🏛️  Legal NLP Pipeline - SIMD Ultra-Fast Hearsay Detection")
+	fmt.Println("🔵 CGO unavailable: using the pure-Go Aho-Corasick fallback")
+
+	// Parse command line arguments
+	var patternsFile string
+	var mode string = "interactive"
+	workers := 1
+
+	for i, arg := range os.Args[1:] {
+		switch arg {
+		case "--patterns", "-p":
+			if i+1 < len(os.Args)-1 {
+				patternsFile = os.Args[i+2]
+			}
+		case "--benchmark", "-b":
+			mode = "benchmark"
+		case "--test", "-t":
+			mode = "test"
+		case "--workers":
+			if i+1 < len(os.Args)-1 {
+				if n, err := strconv.Atoi(os.Args[i+2]); err == nil && n > 0 {
+					workers = n
+				}
+			}
+		case "--simd":
+			// SIMD mode flag (this function runs for --simd)
+		case "--help", "-h":
+			fmt.Println("\nUsage:")
+			fmt.Println("  legal-nlp --simd [options]")
+			fmt.Println("\nOptions:")
+			fmt.Println("  --patterns, -p FILE    Load patterns from file")
+			fmt.Println("  --benchmark, -b        Run benchmark")
+			fmt.Println("  --workers N            Size the worker pool used by --benchmark")
+			fmt.Println("  --test, -t             Run test cases")
+			fmt.Println("  --help, -h             Show this help")
+			fmt.Println("\nFeatures:")
+			fmt.Println("  • Pure-Go Aho-Corasick fallback (CGO/AVX/NEON unavailable)")
+			fmt.Println("  • Same SIMDMatcher API and MatchResult layout as the CGO core")
+			return
+		}
+	}
+
+	// Initialize matcher
+	matcher, err := NewSIMDMatcher(patternsFile)
+	if err != nil {
+		fmt.Printf("❌ Failed to initialize SIMD matcher: %v\n", err)
+		return
+	}
+	defer matcher.Cleanup()
+
+	fmt.Printf("📚 SIMD matcher loaded with %d patterns\n", len(matcher.patterns))
+
+	// Performance tracking
+	var totalSearches, totalMatches int64
+	var totalTime time.Duration
+
+	// Handle different modes
+	switch mode {
+	case "benchmark":
+		if workers > 1 {
+			runSIMDBatchBenchmark(matcher, workers)
+		} else {
+			runSIMDBenchmark(matcher)
+		}
+		return
+	case "test":
+		testCases := []string{
+			"he said the defendant was guilty",
+			"according to witnesses, the meeting was productive",
+			"clean legal text with no hearsay",
+			"she told me about the contract terms",
+			"plaintiff claims damages in the amount of fifty thousand dollars",
+			"witness testified that the events occurred as described",
+		}
+
+		fmt.Println("\n🧪 Running SIMD test cases...")
+		for _, testCase := range testCases {
+			fmt.Printf("\nInput: \"%s\"\n", testCase)
+			results, duration, err := matcher.Search(testCase)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+			formatResults(testCase, results, duration, nil)
+			totalSearches++
+			totalMatches += int64(len(results))
+			totalTime += duration
+		}
+
+		displaySIMDStats(matcher, totalSearches, totalMatches, totalTime)
+		return
+	}
+
+	// Interactive mode
+	fmt.Println("\n💬 SIMD Interactive Mode - Type legal text and press Enter")
+	fmt.Println("📝 Commands: 'stats' (show stats), 'clear' (clear cache), 'quit' (exit)")
+	fmt.Println()
+
+	scanner := bufio.NewScanner(os.Stdin)
+
+	for {
+		fmt.Print("> ")
+
+		if !scanner.Scan() {
+			break
+		}
+
+		input := strings.TrimSpace(scanner.Text())
+
+		if input == "" {
+			continue
+		}
+
+		switch strings.ToLower(input) {
+		case "quit", "exit", "q":
+			fmt.Println("👋 Goodbye!")
+			return
+		case "stats", "s":
+			displaySIMDStats(matcher, totalSearches, totalMatches, totalTime)
+			continue
+		case "clear", "c":
+			matcher.cache.Clear()
+			totalSearches = 0
+			totalMatches = 0
+			totalTime = 0
+			fmt.Println("🗑️  Cache and stats cleared")
+			continue
+		case "help", "h":
+			fmt.Println("Commands:")
+			fmt.Println("  stats/s  - Show SIMD performance statistics")
+			fmt.Println("  clear/c  - Clear cache and reset stats")
+			fmt.Println("  quit/q   - Exit the program")
+			continue
+		}
+
+		results, duration, err := matcher.Search(input)
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			continue
+		}
+
+		totalSearches++
+		totalMatches += int64(len(results))
+		totalTime += duration
+
+		formatResults(input, results, duration, nil)
+
+		cacheStats := matcher.GetCacheStats()
+		cached := ""
+		if cacheStats.Hits > 0 {
+			cached = fmt.Sprintf(" | Cache: %.0f%% hit", matcher.cache.HitRatio())
+		}
+		fmt.Printf("📊 Searches: %d | Matches: %d%s | SIMD: pure-Go\n\n", totalSearches, totalMatches, cached)
+	}
 }